@@ -15,6 +15,9 @@ import (
 
 var ErrUnauthorized = status.Error(codes.Unauthenticated, "auth.unauthorized")
 
+// ErrPermissionDenied 角色不满足方法规则
+var ErrPermissionDenied = status.Error(codes.PermissionDenied, "common.permissionDeny")
+
 // Authorization AUTH_JWT_TOKEN 常量定义
 const (
 	Authorization = "Authorization"
@@ -29,38 +32,102 @@ type Claims struct {
 	Iat      int64  `json:"iat"`
 	Exp      int64  `json:"exp"`
 	ClientIp string `json:"clientIp"`
+	Jti      string `json:"jti"`
+	// TokenType 令牌类型 access / refresh, 旧 token 为空视为 access
+	TokenType string `json:"tokenType"`
+	// FamilyId 刷新令牌族标识, 同一次登录轮换出的令牌共享
+	FamilyId string `json:"familyId"`
 }
 
-// UnaryAuthInterceptor 返回 RPC 拦截器
-func UnaryAuthInterceptor(skipMethods []string, secretKey string) grpc.UnaryServerInterceptor {
+// UnaryAuthInterceptor 返回 RPC 拦截器, provider 提供校验 token 签名的密钥,
+// 使用共享密钥时传入 jwt.NewHMACKeyProvider(secretKey)。
+// skipMethods 支持完整方法名 /pkg.Service/Method 与服务通配 /pkg.Service/*
+func UnaryAuthInterceptor(skipMethods []string, provider jwt.KeyProvider, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts...)
+
 	return func(
 		ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		if containsMethod(skipMethods, info.FullMethod) {
-			return handler(ctx, req)
-		}
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			logx.Errorf("failed to get metadata from context")
-			return nil, ErrUnauthorized
-		}
-		tokens := md.Get(Authorization)
-		if len(tokens) <= common.Zero {
-			return nil, ErrUnauthorized
-		}
-		_, err := ValidateToken(tokens[common.Zero], secretKey)
+		ctx, err := o.authenticate(ctx, info.FullMethod, skipMethods, provider)
 		if err != nil {
-			logx.Errorf("unauthorized err = %s\n", err)
-			return nil, ErrUnauthorized
+			return nil, err
 		}
 		return handler(ctx, req)
 	}
 }
 
-func ValidateToken(token, secretKey string) (Claims, error) {
+// StreamAuthInterceptor 返回流式 RPC 拦截器, 认证规则与 UnaryAuthInterceptor 相同
+func StreamAuthInterceptor(skipMethods []string, provider jwt.KeyProvider, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts...)
+
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := o.authenticate(ss.Context(), info.FullMethod, skipMethods, provider)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authServerStream 替换 ServerStream 的 context, 使 handler 能读取认证后的身份信息
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate 校验 token 并返回写入身份信息的 context
+func (o *options) authenticate(ctx context.Context, fullMethod string, skipMethods []string,
+	provider jwt.KeyProvider) (context.Context, error) {
+	if containsMethod(skipMethods, fullMethod) {
+		return ctx, nil
+	}
+
+	rule, hasRule := o.rules.Match(fullMethod)
+	if hasRule && rule.Skip {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		logx.Errorf("failed to get metadata from context")
+		return nil, ErrUnauthorized
+	}
+	tokens := md.Get(Authorization)
+	if len(tokens) <= common.Zero {
+		return nil, ErrUnauthorized
+	}
+	claims, err := ValidateToken(tokens[common.Zero], provider)
+	if err != nil {
+		logx.Errorf("unauthorized err = %s\n", err)
+		return nil, ErrUnauthorized
+	}
+	if err = o.checkRevocation(ctx, jwt.StripBearerPrefixFromToken(tokens[common.Zero]), claims); err != nil {
+		return nil, err
+	}
+	if hasRule && !rule.allowRoles(claims.RoleId) {
+		logx.Errorw("permission denied", logx.Field("method", fullMethod), logx.Field("roleId", claims.RoleId))
+		return nil, ErrPermissionDenied
+	}
+
+	// 使用 token 中已校验的身份信息, 覆盖客户端透传的 metadata
+	return NewContextWithClaims(ctx, claims), nil
+}
+
+// ValidateToken 校验 token 签名与有效期并解析出 Claims, 支持 HS256/RS256/ES256/EdDSA,
+// 按 token header 中的 kid 从 provider 选择校验密钥
+func ValidateToken(token string, provider jwt.KeyProvider) (Claims, error) {
 	var claims Claims
 	if token == common.EmptyString {
 		return claims, errors.New("token is empty")
@@ -69,7 +136,7 @@ func ValidateToken(token, secretKey string) (Claims, error) {
 	if fromToken == common.EmptyString {
 		return claims, errors.New("token is empty")
 	}
-	jwtMaps, err := jwt.ParseJwtToken(fromToken, secretKey)
+	jwtMaps, err := jwt.ParseJwtTokenWithProvider(fromToken, provider)
 	//打印maps
 	logx.Infof("jwtMaps = %v", jwtMaps)
 	if err != nil {
@@ -80,12 +147,15 @@ func ValidateToken(token, secretKey string) (Claims, error) {
 		logx.Errorf("MapClaimsToStruct error = %v", err)
 		return claims, errors.New("MapClaimsToStruct error")
 	}
+	if claims.TokenType == TokenTypeRefresh {
+		return claims, errors.New("refresh token cannot be used as access token")
+	}
 	return claims, nil
 }
 
 func containsMethod(methods []string, target string) bool {
 	for _, m := range methods {
-		if m == target {
+		if MatchMethod(m, target) {
 			return true
 		}
 	}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/enum/common"
+)
+
+type claimsCtxKey struct{}
+
+// NewContextWithClaims 将校验通过的 Claims 写入 context, 供 userctx / rolectx / deptctx / tenantctx 读取。
+//
+// 同时覆盖 incoming metadata 中的同名身份字段, claims 中没有的字段会被删除,
+// 避免调用方通过 metadata 伪造用户、角色、部门、租户或客户端 IP。
+func NewContextWithClaims(ctx context.Context, claims Claims) context.Context {
+	var deptId, tenantId string
+	if claims.DeptId != common.Zero {
+		deptId = strconv.FormatUint(claims.DeptId, common.Ten)
+	}
+	if claims.TenantId != common.Zero {
+		tenantId = strconv.FormatUint(claims.TenantId, common.Ten)
+	}
+
+	ctx = context.WithValue(ctx, claimsCtxKey{}, claims)
+	if claims.UserId != common.EmptyString {
+		ctx = context.WithValue(ctx, common.CtxKeyUserID, claims.UserId)
+	}
+	if claims.RoleId != common.EmptyString {
+		ctx = context.WithValue(ctx, common.CtxKeyRoleID, claims.RoleId)
+	}
+	if deptId != common.EmptyString {
+		// deptctx 按 go-zero jwt 的解析结果读取 json.Number
+		ctx = context.WithValue(ctx, common.CtxKeyDeptID, json.Number(deptId))
+	}
+	if tenantId != common.EmptyString {
+		ctx = context.WithValue(ctx, enum.TenantIdCtxKey, tenantId)
+	}
+	if claims.ClientIp != common.EmptyString {
+		ctx = context.WithValue(ctx, enum.ClientIPCtxKey, claims.ClientIp)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	overrideMetadata(md, enum.UserIdRpcCtxKey, claims.UserId)
+	overrideMetadata(md, enum.RoleIdRpcCtxKey, claims.RoleId)
+	overrideMetadata(md, enum.DepartmentIdRpcCtxKey, deptId)
+	overrideMetadata(md, enum.TenantIdCtxKey, tenantId)
+	overrideMetadata(md, enum.ClientIPCtxKey, claims.ClientIp)
+
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// ClaimsFromContext 返回认证拦截器写入 context 的 Claims
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsCtxKey{}).(Claims)
+	return claims, ok
+}
+
+func overrideMetadata(md metadata.MD, key, val string) {
+	if val == common.EmptyString {
+		md.Delete(key)
+		return
+	}
+	md.Set(key, val)
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/orm/ent/entctx/datapermctx"
+	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
+	"mingyang.com/admin-common/orm/ent/entctx/rolectx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+	"mingyang.com/admin-common/utils/jwt"
+)
+
+func TestUnaryAuthInterceptorClaimsContext(t *testing.T) {
+	interceptor := UnaryAuthInterceptor(nil, jwt.NewHMACKeyProvider(testSecret))
+	info := &grpc.UnaryServerInfo{FullMethod: "/core.Core/GetUser"}
+
+	token := newTestToken(t, time.Now().Unix(),
+		jwt.WithOption("userId", "u1"),
+		jwt.WithOption("roleId", "admin,editor"),
+		jwt.WithOption("deptId", 3),
+		jwt.WithOption("jwtTenantId", 10),
+		jwt.WithOption("clientIp", "10.0.0.1"))
+
+	// forged identity in metadata must be overridden by the token
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		Authorization, token,
+		enum.UserIdRpcCtxKey, "forged",
+		enum.TenantIdCtxKey, "99",
+		enum.DepartmentIdRpcCtxKey, "99",
+	))
+
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		userId, err := userctx.GetUserIDFromCtx(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "u1", userId)
+
+		roleIds, err := rolectx.GetRoleIDFromCtx(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"admin", "editor"}, roleIds)
+
+		deptId, err := deptctx.GetDepartmentIDFromCtx(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(3), deptId)
+
+		assert.Equal(t, uint64(10), tenantctx.GetTenantIDFromCtx(ctx))
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		assert.Equal(t, []string{"u1"}, md.Get(enum.UserIdRpcCtxKey))
+		assert.Equal(t, []string{"10.0.0.1"}, md.Get(enum.ClientIPCtxKey))
+
+		claims, ok := ClaimsFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "u1", claims.UserId)
+		return nil, nil
+	})
+	assert.Nil(t, err)
+}
+
+func TestNewContextWithClaimsRemovesForgedMetadata(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		enum.DepartmentIdRpcCtxKey, "99",
+		enum.TenantIdCtxKey, "99",
+		string(tenantctx.TenantAdmin), "allow",
+		string(datapermctx.ScopeKey), "1",
+		"trace-id", "t1",
+	))
+	ctx = NewContextWithClaims(ctx, Claims{UserId: "u1"})
+
+	_, err := deptctx.GetDepartmentIDFromCtx(ctx)
+	assert.NotNil(t, err)
+	md, _ := metadata.FromIncomingContext(ctx)
+	assert.Empty(t, md.Get(enum.TenantIdCtxKey))
+
+	// the other metadata, such as the admin flag and data scope set by upstream services, is kept
+	assert.True(t, tenantctx.GetTenantAdminCtx(ctx))
+	_, err = datapermctx.GetScopeFromCtx(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"t1"}, md.Get("trace-id"))
+
+	_, ok := ClaimsFromContext(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"mingyang.com/admin-common/enum/common"
+)
+
+// MethodRule 方法认证规则, 可直接从配置文件加载:
+//
+//	AuthRules:
+//	  - Method: /core.Core/Login
+//	    Skip: true
+//	  - Method: /core.Core/*
+//	    Roles: [admin]
+//	  - Method: ^/export\.Export/Export.*$
+//	    Regex: true
+//	    Roles: [admin, auditor]
+type MethodRule struct {
+	// Method 完整方法名 /pkg.Service/Method、服务通配 /pkg.Service/* 或 * 表示全部方法,
+	// Regex 为 true 时为正则表达式
+	Method string
+	// Regex Method 是否为正则表达式
+	Regex bool `json:",optional"`
+	// Skip 为 true 时跳过认证
+	Skip bool `json:",optional"`
+	// Roles 访问方法需要的角色编码, 满足任一即可, 为空时只需认证
+	Roles []string `json:",optional"`
+}
+
+// MethodRules 编译后的方法规则, 按配置顺序匹配, 第一条匹配的规则生效
+type MethodRules struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	MethodRule
+	regex *regexp.Regexp
+}
+
+// NewMethodRules 编译方法规则, 正则表达式错误时返回 error
+func NewMethodRules(rules []MethodRule) (*MethodRules, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, v := range rules {
+		rule := compiledRule{MethodRule: v}
+		if v.Regex {
+			reg, err := regexp.Compile(v.Method)
+			if err != nil {
+				return nil, fmt.Errorf("invalid method rule %q: %w", v.Method, err)
+			}
+			rule.regex = reg
+		}
+		compiled = append(compiled, rule)
+	}
+
+	return &MethodRules{rules: compiled}, nil
+}
+
+// Match 返回第一条匹配方法的规则
+func (m *MethodRules) Match(fullMethod string) (*MethodRule, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	for i := range m.rules {
+		rule := &m.rules[i]
+		if rule.regex != nil {
+			if rule.regex.MatchString(fullMethod) {
+				return &rule.MethodRule, true
+			}
+		} else if MatchMethod(rule.Method, fullMethod) {
+			return &rule.MethodRule, true
+		}
+	}
+
+	return nil, false
+}
+
+// allowRoles 判断逗号分隔的角色编码中是否包含规则要求的任一角色
+func (r *MethodRule) allowRoles(roleIds string) bool {
+	if len(r.Roles) == common.Zero {
+		return true
+	}
+
+	for _, v := range strings.Split(roleIds, common.Comma) {
+		if slices.Contains(r.Roles, strings.TrimSpace(v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WithMethodRules 设置方法认证规则, 规则无效时退出
+func WithMethodRules(rules []MethodRule) Option {
+	compiled, err := NewMethodRules(rules)
+	logx.Must(err)
+
+	return func(o *options) {
+		o.rules = compiled
+	}
+}
+
+// MatchMethod 判断方法名是否匹配, 支持完整方法名、服务通配 /pkg.Service/* 与 *
+func MatchMethod(pattern, fullMethod string) bool {
+	if pattern == common.Star || pattern == fullMethod {
+		return true
+	}
+
+	if prefix, ok := strings.CutSuffix(pattern, common.Slash+common.Star); ok {
+		return strings.HasPrefix(fullMethod, prefix+common.Slash)
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+	"mingyang.com/admin-common/utils/jwt"
+)
+
+func TestMatchMethod(t *testing.T) {
+	tests := []struct {
+		pattern string
+		method  string
+		want    bool
+	}{
+		{pattern: "/core.Core/Login", method: "/core.Core/Login", want: true},
+		{pattern: "/core.Core/Login", method: "/core.Core/LoginByEmail", want: false},
+		{pattern: "/core.Core/*", method: "/core.Core/Login", want: true},
+		{pattern: "/core.Core/*", method: "/core.CoreExt/Login", want: false},
+		{pattern: "*", method: "/core.Core/Login", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+tt.method, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchMethod(tt.pattern, tt.method))
+		})
+	}
+}
+
+func TestMethodRules(t *testing.T) {
+	rules, err := NewMethodRules([]MethodRule{
+		{Method: "/core.Core/Login", Skip: true},
+		{Method: `^/export\.Export/Export.*$`, Regex: true, Roles: []string{"auditor"}},
+		{Method: "/core.Core/*", Roles: []string{"admin"}},
+	})
+	assert.Nil(t, err)
+
+	rule, ok := rules.Match("/core.Core/Login")
+	assert.True(t, ok)
+	assert.True(t, rule.Skip)
+
+	rule, ok = rules.Match("/export.Export/ExportUser")
+	assert.True(t, ok)
+	assert.True(t, rule.allowRoles("admin,auditor"))
+	assert.False(t, rule.allowRoles("admin"))
+
+	_, ok = rules.Match("/file.File/Upload")
+	assert.False(t, ok)
+
+	_, err = NewMethodRules([]MethodRule{{Method: "(", Regex: true}})
+	assert.NotNil(t, err)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamAuthInterceptor(t *testing.T) {
+	interceptor := StreamAuthInterceptor([]string{"/core.Core/Login"}, jwt.NewHMACKeyProvider(testSecret),
+		WithMethodRules([]MethodRule{
+			{Method: "/export.Export/*", Roles: []string{"auditor"}},
+			{Method: "/core.Core/Subscribe", Skip: true},
+		}))
+
+	token := newTestToken(t, time.Now().Unix(), jwt.WithOption("userId", "u1"), jwt.WithOption("roleId", "admin"))
+	authCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(Authorization, token))
+
+	var userId string
+	handler := func(srv any, ss grpc.ServerStream) error {
+		userId, _ = userctx.GetUserIDFromCtx(ss.Context())
+		return nil
+	}
+
+	err := interceptor(nil, &testServerStream{ctx: authCtx}, &grpc.StreamServerInfo{FullMethod: "/core.Core/Export"}, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "u1", userId)
+
+	err = interceptor(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/core.Core/Export"}, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	err = interceptor(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/core.Core/Subscribe"}, handler)
+	assert.Nil(t, err)
+
+	err = interceptor(nil, &testServerStream{ctx: authCtx}, &grpc.StreamServerInfo{FullMethod: "/export.Export/ExportUser"}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTokenRevoked token 已被吊销
+var ErrTokenRevoked = status.Error(codes.Unauthenticated, "auth.tokenRevoked")
+
+// Option 认证拦截器可选项
+type Option func(*options)
+
+type options struct {
+	revocation RevocationStore
+	rules      *MethodRules
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithRevocationStore 设置吊销存储, 校验签名后再检查 token 是否已被吊销
+func WithRevocationStore(store RevocationStore) Option {
+	return func(o *options) {
+		o.revocation = store
+	}
+}
+
+// checkRevocation 吊销存储不可用时返回 Unavailable, 避免 Redis 故障时所有用户被登出
+func (o *options) checkRevocation(ctx context.Context, token string, claims Claims) error {
+	if o.revocation == nil {
+		return nil
+	}
+
+	revoked, err := o.revocation.IsRevoked(ctx, token, claims)
+	if err != nil {
+		logx.Errorw("failed to check token revocation", logx.Field("detail", err.Error()))
+		return status.Error(codes.Unavailable, "common.redisError")
+	}
+
+	if revoked {
+		return ErrTokenRevoked
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"mingyang.com/admin-common/config"
+)
+
+var (
+	// ErrRefreshFamilyNotFound 刷新令牌族不存在或已过期
+	ErrRefreshFamilyNotFound = errors.New("refresh token family not found")
+	// ErrRefreshTokenReplayed 刷新令牌不是令牌族当前的令牌, 即已被使用过
+	ErrRefreshTokenReplayed = errors.New("refresh token has been used")
+)
+
+// RefreshFamily 刷新令牌族, 记录一次登录当前有效的刷新令牌与访问令牌
+type RefreshFamily struct {
+	// Id 令牌族标识
+	Id string
+	// UserId 令牌所属用户
+	UserId string
+	// RefreshJti 当前有效刷新令牌的 jti
+	RefreshJti string
+	// AccessJti 与当前刷新令牌一起签发的访问令牌 jti
+	AccessJti string
+}
+
+// RefreshTokenStore 刷新令牌族存储
+type RefreshTokenStore interface {
+	// Create 保存新的令牌族
+	Create(ctx context.Context, family RefreshFamily, ttl time.Duration) error
+	// Rotate 令牌族当前刷新令牌为 oldJti 时原子替换为 newRefreshJti / newAccessJti 并返回替换前的令牌族;
+	// 令牌族不存在返回 ErrRefreshFamilyNotFound, oldJti 不是当前令牌返回 ErrRefreshTokenReplayed
+	Rotate(ctx context.Context, familyId, oldJti, newRefreshJti, newAccessJti string, ttl time.Duration) (RefreshFamily, error)
+	// Delete 删除令牌族并返回删除前的内容, 令牌族不存在返回 ErrRefreshFamilyNotFound
+	Delete(ctx context.Context, familyId string) (RefreshFamily, error)
+}
+
+// RefreshFamilyKey 返回刷新令牌族的 key
+func RefreshFamilyKey(familyId string) string {
+	return config.RedisRefreshTokenPrefix + familyId
+}
+
+// rotateScript 比较并替换当前刷新令牌, 返回 {状态, 旧 access jti, user};
+// 状态 -1 令牌族不存在, 0 令牌已被使用, 1 替换成功
+var rotateScript = redis.NewScript(`
+local cur = redis.call("HMGET", KEYS[1], "refresh", "access", "user")
+if not cur[1] then
+	return {-1, "", ""}
+end
+if cur[1] ~= ARGV[1] then
+	return {0, "", ""}
+end
+redis.call("HSET", KEYS[1], "refresh", ARGV[2], "access", ARGV[3])
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return {1, cur[2] or "", cur[3] or ""}
+`)
+
+// deleteScript 读取并删除令牌族, 令牌族不存在时返回空
+var deleteScript = redis.NewScript(`
+local family = redis.call("HGETALL", KEYS[1])
+redis.call("DEL", KEYS[1])
+return family
+`)
+
+// RedisRefreshTokenStore 基于 Redis 的刷新令牌族存储, 每个令牌族为一个 hash:
+//
+//	REFRESH:FAMILY:{familyId} -> {user, refresh, access}
+//
+// 轮换通过 Lua 脚本比较并替换, 并发使用同一刷新令牌时只有一个请求成功。
+type RedisRefreshTokenStore struct {
+	rds redis.UniversalClient
+}
+
+// NewRedisRefreshTokenStore 创建 Redis 刷新令牌族存储
+func NewRedisRefreshTokenStore(rds redis.UniversalClient) *RedisRefreshTokenStore {
+	return &RedisRefreshTokenStore{rds: rds}
+}
+
+func (s *RedisRefreshTokenStore) Create(ctx context.Context, family RefreshFamily, ttl time.Duration) error {
+	key := RefreshFamilyKey(family.Id)
+	_, err := s.rds.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "user", family.UserId, "refresh", family.RefreshJti, "access", family.AccessJti)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisRefreshTokenStore) Rotate(ctx context.Context, familyId, oldJti, newRefreshJti, newAccessJti string,
+	ttl time.Duration) (RefreshFamily, error) {
+	result, err := rotateScript.Run(ctx, s.rds, []string{RefreshFamilyKey(familyId)},
+		oldJti, newRefreshJti, newAccessJti, ttl.Milliseconds()).Slice()
+	if err != nil {
+		return RefreshFamily{}, err
+	}
+
+	code, _ := result[0].(int64)
+	switch code {
+	case -1:
+		return RefreshFamily{}, ErrRefreshFamilyNotFound
+	case 0:
+		return RefreshFamily{}, ErrRefreshTokenReplayed
+	}
+
+	access, _ := result[1].(string)
+	userId, _ := result[2].(string)
+	return RefreshFamily{Id: familyId, UserId: userId, RefreshJti: oldJti, AccessJti: access}, nil
+}
+
+func (s *RedisRefreshTokenStore) Delete(ctx context.Context, familyId string) (RefreshFamily, error) {
+	result, err := deleteScript.Run(ctx, s.rds, []string{RefreshFamilyKey(familyId)}).StringSlice()
+	if err != nil {
+		return RefreshFamily{}, err
+	}
+	if len(result) == 0 {
+		return RefreshFamily{}, ErrRefreshFamilyNotFound
+	}
+
+	family := RefreshFamily{Id: familyId}
+	for i := 0; i+1 < len(result); i += 2 {
+		switch result[i] {
+		case "user":
+			family.UserId = result[i+1]
+		case "refresh":
+			family.RefreshJti = result[i+1]
+		case "access":
+			family.AccessJti = result[i+1]
+		}
+	}
+	return family, nil
+}
+
+// MemoryRefreshTokenStore 内存刷新令牌族存储, 用于测试或单实例部署
+type MemoryRefreshTokenStore struct {
+	mu       sync.Mutex
+	families map[string]memoryRefreshFamily
+}
+
+type memoryRefreshFamily struct {
+	RefreshFamily
+	expireAt time.Time
+}
+
+// NewMemoryRefreshTokenStore 创建内存刷新令牌族存储
+func NewMemoryRefreshTokenStore() *MemoryRefreshTokenStore {
+	return &MemoryRefreshTokenStore{families: make(map[string]memoryRefreshFamily)}
+}
+
+func (s *MemoryRefreshTokenStore) Create(_ context.Context, family RefreshFamily, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.families[family.Id] = memoryRefreshFamily{RefreshFamily: family, expireAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (s *MemoryRefreshTokenStore) Rotate(_ context.Context, familyId, oldJti, newRefreshJti, newAccessJti string,
+	ttl time.Duration) (RefreshFamily, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	family, ok := s.load(familyId)
+	if !ok {
+		return RefreshFamily{}, ErrRefreshFamilyNotFound
+	}
+	if family.RefreshJti != oldJti {
+		return RefreshFamily{}, ErrRefreshTokenReplayed
+	}
+
+	previous := family.RefreshFamily
+	family.RefreshJti, family.AccessJti, family.expireAt = newRefreshJti, newAccessJti, time.Now().Add(ttl)
+	s.families[familyId] = family
+	return previous, nil
+}
+
+func (s *MemoryRefreshTokenStore) Delete(_ context.Context, familyId string) (RefreshFamily, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	family, ok := s.load(familyId)
+	if !ok {
+		return RefreshFamily{}, ErrRefreshFamilyNotFound
+	}
+	delete(s.families, familyId)
+	return family.RefreshFamily, nil
+}
+
+func (s *MemoryRefreshTokenStore) load(familyId string) (memoryRefreshFamily, bool) {
+	family, ok := s.families[familyId]
+	if ok && time.Now().After(family.expireAt) {
+		delete(s.families, familyId)
+		return family, false
+	}
+	return family, ok
+}
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"mingyang.com/admin-common/config"
+	"mingyang.com/admin-common/enum/common"
+)
+
+// RevocationStore 令牌吊销存储, 用于登出、强制下线和租户停用
+type RevocationStore interface {
+	// IsRevoked 判断 token 是否已被吊销(单个 token、用户全部 token 或整个租户)
+	IsRevoked(ctx context.Context, token string, claims Claims) (bool, error)
+	// RevokeToken 吊销单个 token, tokenId 为 jti, ttl 应不小于 token 剩余有效期
+	RevokeToken(ctx context.Context, tokenId string, ttl time.Duration) error
+	// RevokeUser 吊销用户此刻及之前签发的全部 token, ttl 应不小于 token 最长有效期
+	RevokeUser(ctx context.Context, userId string, ttl time.Duration) error
+	// RevokeTenant 吊销租户的全部 token, ttl 为 0 时永久有效直到 RestoreTenant
+	RevokeTenant(ctx context.Context, tenantId uint64, ttl time.Duration) error
+	// RestoreTenant 解除租户吊销
+	RestoreTenant(ctx context.Context, tenantId uint64) error
+}
+
+// TokenID 返回 token 的吊销标识, 优先使用 jti, 没有 jti 的旧 token 使用 token 原文
+func TokenID(token string, claims Claims) string {
+	if claims.Jti != common.EmptyString {
+		return claims.Jti
+	}
+	return token
+}
+
+// RedisRevocationStore 基于 Redis 的吊销存储, key 分别为:
+//
+//	BLACKLIST:TOKEN:{jti}          单个 token
+//	BLACKLIST:TOKEN:USER:{userId}  用户吊销时间戳
+//	BLACKLIST:TENANT:{tenantId}    租户吊销
+//
+// 查询结果在本地 LRU 中缓存 cacheTTL, 其他实例的吊销最多延迟 cacheTTL 生效。
+type RedisRevocationStore struct {
+	rds      redis.UniversalClient
+	cache    *collection.Cache
+	cacheTTL time.Duration
+}
+
+// NewRedisRevocationStore 创建 Redis 吊销存储, cacheLimit 为本地缓存条目上限,
+// cacheTTL 为本地缓存时间, 为 0 时使用默认 5 秒
+func NewRedisRevocationStore(rds redis.UniversalClient, cacheLimit int, cacheTTL time.Duration) (*RedisRevocationStore, error) {
+	if cacheTTL <= 0 {
+		cacheTTL = 5 * time.Second
+	}
+	if cacheLimit <= 0 {
+		cacheLimit = 10000
+	}
+
+	cache, err := collection.NewCache(cacheTTL, collection.WithLimit(cacheLimit), collection.WithName("token-revocation"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisRevocationStore{rds: rds, cache: cache, cacheTTL: cacheTTL}, nil
+}
+
+// MustNewRedisRevocationStore 创建 Redis 吊销存储, 出错时退出
+func MustNewRedisRevocationStore(rds redis.UniversalClient) *RedisRevocationStore {
+	store, err := NewRedisRevocationStore(rds, 0, 0)
+	logx.Must(err)
+	return store
+}
+
+// RevokedTokenKey 返回单个 token 的吊销 key
+func RevokedTokenKey(tokenId string) string {
+	return config.RedisTokenPrefix + tokenId
+}
+
+// RevokedUserKey 返回用户吊销时间戳的 key
+func RevokedUserKey(userId string) string {
+	return config.RedisTokenPrefix + "USER:" + userId
+}
+
+// RevokedTenantKey 返回租户吊销的 key
+func RevokedTenantKey(tenantId uint64) string {
+	return config.RedisTenantBlacklistPrefix + strconv.FormatUint(tenantId, 10)
+}
+
+func (s *RedisRevocationStore) IsRevoked(ctx context.Context, token string, claims Claims) (bool, error) {
+	keys := []string{RevokedTokenKey(TokenID(token, claims)), RevokedTenantKey(claims.TenantId)}
+	if claims.UserId != common.EmptyString {
+		keys = append(keys, RevokedUserKey(claims.UserId))
+	}
+
+	values := make(map[string]int64, len(keys))
+	var missing []string
+	for _, key := range keys {
+		if v, ok := s.cache.Get(key); ok {
+			values[key] = v.(int64)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		cmds := make([]*redis.StringCmd, len(missing))
+		_, err := s.rds.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range missing {
+				cmds[i] = pipe.Get(ctx, key)
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return false, err
+		}
+
+		for i, key := range missing {
+			var v int64
+			if val, err := cmds[i].Result(); err == nil {
+				// 兼容只写入 "1" 等非时间戳的值
+				if v, err = strconv.ParseInt(val, 10, 64); err != nil || v <= 0 {
+					v = 1
+				}
+			}
+			values[key] = v
+			s.cache.Set(key, v)
+		}
+	}
+
+	return isRevoked(claims, values[keys[0]], values[keys[1]], values[RevokedUserKey(claims.UserId)]), nil
+}
+
+func (s *RedisRevocationStore) RevokeToken(ctx context.Context, tokenId string, ttl time.Duration) error {
+	key := RevokedTokenKey(tokenId)
+	if err := s.rds.Set(ctx, key, time.Now().Unix(), ttl).Err(); err != nil {
+		return err
+	}
+	s.cache.SetWithExpire(key, time.Now().Unix(), max(ttl, s.cacheTTL))
+	return nil
+}
+
+func (s *RedisRevocationStore) RevokeUser(ctx context.Context, userId string, ttl time.Duration) error {
+	key := RevokedUserKey(userId)
+	now := time.Now().Unix()
+	if err := s.rds.Set(ctx, key, now, ttl).Err(); err != nil {
+		return err
+	}
+	s.cache.Set(key, now)
+	return nil
+}
+
+func (s *RedisRevocationStore) RevokeTenant(ctx context.Context, tenantId uint64, ttl time.Duration) error {
+	key := RevokedTenantKey(tenantId)
+	now := time.Now().Unix()
+	if err := s.rds.Set(ctx, key, now, ttl).Err(); err != nil {
+		return err
+	}
+	s.cache.Set(key, now)
+	return nil
+}
+
+func (s *RedisRevocationStore) RestoreTenant(ctx context.Context, tenantId uint64) error {
+	key := RevokedTenantKey(tenantId)
+	if err := s.rds.Del(ctx, key).Err(); err != nil {
+		return err
+	}
+	s.cache.Del(key)
+	return nil
+}
+
+// MemoryRevocationStore 内存吊销存储, 用于测试或单实例部署
+type MemoryRevocationStore struct {
+	mu      sync.RWMutex
+	entries map[string]revocationEntry
+}
+
+type revocationEntry struct {
+	revokedAt int64
+	expireAt  time.Time
+}
+
+// NewMemoryRevocationStore 创建内存吊销存储
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{entries: make(map[string]revocationEntry)}
+}
+
+func (s *MemoryRevocationStore) IsRevoked(_ context.Context, token string, claims Claims) (bool, error) {
+	return isRevoked(claims,
+		s.get(RevokedTokenKey(TokenID(token, claims))),
+		s.get(RevokedTenantKey(claims.TenantId)),
+		s.get(RevokedUserKey(claims.UserId))), nil
+}
+
+func (s *MemoryRevocationStore) RevokeToken(_ context.Context, tokenId string, ttl time.Duration) error {
+	s.set(RevokedTokenKey(tokenId), ttl)
+	return nil
+}
+
+func (s *MemoryRevocationStore) RevokeUser(_ context.Context, userId string, ttl time.Duration) error {
+	s.set(RevokedUserKey(userId), ttl)
+	return nil
+}
+
+func (s *MemoryRevocationStore) RevokeTenant(_ context.Context, tenantId uint64, ttl time.Duration) error {
+	s.set(RevokedTenantKey(tenantId), ttl)
+	return nil
+}
+
+func (s *MemoryRevocationStore) RestoreTenant(_ context.Context, tenantId uint64) error {
+	s.mu.Lock()
+	delete(s.entries, RevokedTenantKey(tenantId))
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryRevocationStore) get(key string) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.entries[key]
+	if !ok || (!entry.expireAt.IsZero() && time.Now().After(entry.expireAt)) {
+		return 0
+	}
+	return entry.revokedAt
+}
+
+func (s *MemoryRevocationStore) set(key string, ttl time.Duration) {
+	entry := revocationEntry{revokedAt: time.Now().Unix()}
+	if ttl > 0 {
+		entry.expireAt = time.Now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	s.entries[key] = entry
+	s.mu.Unlock()
+}
+
+// isRevoked 判断吊销状态, 值为 0 表示未吊销; 用户吊销只影响吊销时刻及之前签发的 token,
+// 修改密码后重新签发的 token 不受影响。iat 精确到秒, 与吊销同一秒签发的 token 无法区分先后, 按已吊销处理
+func isRevoked(claims Claims, token, tenant, user int64) bool {
+	if token > 0 || tenant > 0 {
+		return true
+	}
+	return user > 0 && claims.Iat <= user
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/utils/jwt"
+)
+
+const testSecret = "jS6VKDtsJf3z1n2VKDtsJf3z1n2"
+
+func newTestToken(t *testing.T, iat int64, opts ...jwt.Option) string {
+	token, err := jwt.NewJwtToken(testSecret, iat, 3600, opts...)
+	assert.Nil(t, err)
+	return token
+}
+
+func TestMemoryRevocationStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRevocationStore()
+	now := time.Now().Unix()
+
+	claims := Claims{UserId: "u1", TenantId: 2, Iat: now - 10, Jti: "jti-1"}
+
+	revoked, _ := store.IsRevoked(ctx, "token", claims)
+	assert.False(t, revoked)
+
+	assert.Nil(t, store.RevokeToken(ctx, "jti-1", time.Minute))
+	revoked, _ = store.IsRevoked(ctx, "token", claims)
+	assert.True(t, revoked)
+
+	claims.Jti = "jti-2"
+	assert.Nil(t, store.RevokeUser(ctx, "u1", time.Minute))
+	revoked, _ = store.IsRevoked(ctx, "token", claims)
+	assert.True(t, revoked)
+
+	// the token issued in the same second as revoking the user is revoked
+	claims.Iat = store.get(RevokedUserKey("u1"))
+	revoked, _ = store.IsRevoked(ctx, "token", claims)
+	assert.True(t, revoked)
+
+	// the token issued after revoking the user is still valid
+	claims.Iat = now + 10
+	revoked, _ = store.IsRevoked(ctx, "token", claims)
+	assert.False(t, revoked)
+
+	assert.Nil(t, store.RevokeTenant(ctx, 2, 0))
+	revoked, _ = store.IsRevoked(ctx, "token", claims)
+	assert.True(t, revoked)
+
+	assert.Nil(t, store.RestoreTenant(ctx, 2))
+	revoked, _ = store.IsRevoked(ctx, "token", claims)
+	assert.False(t, revoked)
+}
+
+func TestUnaryAuthInterceptorRevocation(t *testing.T) {
+	store := NewMemoryRevocationStore()
+	interceptor := UnaryAuthInterceptor(nil, jwt.NewHMACKeyProvider(testSecret), WithRevocationStore(store))
+	info := &grpc.UnaryServerInfo{FullMethod: "/core.Core/GetUser"}
+	handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	token := newTestToken(t, time.Now().Unix(), jwt.WithOption("userId", "u1"), jwt.WithOption("jti", "jti-1"))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(Authorization, "Bearer "+token))
+
+	resp, err := interceptor(ctx, nil, info, handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", resp)
+
+	assert.Nil(t, store.RevokeToken(context.Background(), "jti-1", time.Hour))
+	_, err = interceptor(ctx, nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/utils/jwt"
+	"mingyang.com/admin-common/utils/uuidx"
+)
+
+// 令牌类型, 写入 Claims.TokenType
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+var (
+	// ErrInvalidRefreshToken 刷新令牌无效、过期或令牌族已失效
+	ErrInvalidRefreshToken = status.Error(codes.Unauthenticated, "auth.invalidToken")
+	// ErrRefreshTokenReused 旧刷新令牌被重放, 整个令牌族已被吊销
+	ErrRefreshTokenReused = status.Error(codes.Unauthenticated, "auth.refreshTokenReused")
+)
+
+// reservedClaims 由签发器写入或由 Claims 类型化字段携带的字段, 刷新时不从旧令牌复制
+var reservedClaims = map[string]struct{}{
+	"iat": {}, "exp": {}, "jti": {}, "tokenType": {}, "familyId": {},
+	"userId": {}, "roleId": {}, "deptId": {}, "jwtTenantId": {}, "clientIp": {},
+}
+
+// TokenIssuerConf 令牌签发配置, 单位为秒
+type TokenIssuerConf struct {
+	// AccessExpire 访问令牌有效期
+	AccessExpire int64 `json:",default=7200"`
+	// RefreshExpire 刷新令牌有效期, 每次刷新后重新计算
+	RefreshExpire int64 `json:",default=604800"`
+}
+
+// TokenPair 访问令牌与刷新令牌
+type TokenPair struct {
+	AccessToken   string `json:"accessToken"`
+	AccessExpire  int64  `json:"accessExpire"`
+	RefreshToken  string `json:"refreshToken"`
+	RefreshExpire int64  `json:"refreshExpire"`
+}
+
+// TokenIssuer 签发与轮换令牌对。
+//
+// 每次登录创建一个令牌族, 刷新时令牌族中的刷新令牌被替换为新令牌;
+// 已被替换的刷新令牌再次使用时视为泄露, 删除令牌族并吊销当前访问令牌。
+type TokenIssuer struct {
+	conf       TokenIssuerConf
+	provider   jwt.KeyProvider
+	store      RefreshTokenStore
+	revocation RevocationStore
+}
+
+// NewTokenIssuer 创建令牌签发器, revocation 为 nil 时令牌族被吊销后当前访问令牌仍有效至过期
+func NewTokenIssuer(conf TokenIssuerConf, provider jwt.KeyProvider, store RefreshTokenStore,
+	revocation RevocationStore) *TokenIssuer {
+	return &TokenIssuer{conf: conf, provider: provider, store: store, revocation: revocation}
+}
+
+// IssuePair 登录时签发令牌对, opts 为额外写入两个令牌的字段
+func (t *TokenIssuer) IssuePair(ctx context.Context, claims Claims, opts ...jwt.Option) (*TokenPair, error) {
+	familyId := uuidx.NewUUID().String()
+	accessJti, refreshJti := uuidx.NewUUID().String(), uuidx.NewUUID().String()
+
+	pair, err := t.sign(append(claimsOptions(claims), opts...), familyId, accessJti, refreshJti)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.store.Create(ctx, RefreshFamily{
+		Id:         familyId,
+		UserId:     claims.UserId,
+		RefreshJti: refreshJti,
+		AccessJti:  accessJti,
+	}, t.refreshTTL())
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Refresh 使用刷新令牌签发新的令牌对, 旧刷新令牌随即失效
+func (t *TokenIssuer) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, mapClaims, err := t.parseRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	accessJti, refreshJti := uuidx.NewUUID().String(), uuidx.NewUUID().String()
+	_, err = t.store.Rotate(ctx, claims.FamilyId, claims.Jti, refreshJti, accessJti, t.refreshTTL())
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRefreshFamilyNotFound):
+			return nil, ErrInvalidRefreshToken
+		case errors.Is(err, ErrRefreshTokenReplayed):
+			logx.Errorw("refresh token reused, revoke token family",
+				logx.Field("familyId", claims.FamilyId), logx.Field("userId", claims.UserId))
+			if err = t.revokeFamily(ctx, claims.FamilyId); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		default:
+			return nil, err
+		}
+	}
+
+	// 类型化字段从 claims 重新写入, 其余自定义字段按原值复制
+	opts := claimsOptions(claims)
+	for k, v := range mapClaims {
+		if _, ok := reservedClaims[k]; !ok {
+			opts = append(opts, jwt.WithOption(k, v))
+		}
+	}
+
+	return t.sign(opts, claims.FamilyId, accessJti, refreshJti)
+}
+
+// Revoke 登出时吊销刷新令牌所在的令牌族及当前访问令牌
+func (t *TokenIssuer) Revoke(ctx context.Context, refreshToken string) error {
+	claims, _, err := t.parseRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+	return t.revokeFamily(ctx, claims.FamilyId)
+}
+
+// parseRefreshToken 校验刷新令牌签名、类型与吊销状态
+func (t *TokenIssuer) parseRefreshToken(ctx context.Context, refreshToken string) (Claims, map[string]any, error) {
+	var claims Claims
+	token := jwt.StripBearerPrefixFromToken(refreshToken)
+	if token == common.EmptyString {
+		return claims, nil, ErrInvalidRefreshToken
+	}
+
+	mapClaims, err := jwt.ParseJwtTokenWithProvider(token, t.provider)
+	if err != nil {
+		return claims, nil, ErrInvalidRefreshToken
+	}
+	if err = jwt.MapClaimsToStruct(mapClaims, &claims); err != nil {
+		return claims, nil, ErrInvalidRefreshToken
+	}
+	if claims.TokenType != TokenTypeRefresh || claims.FamilyId == common.EmptyString || claims.Jti == common.EmptyString {
+		return claims, nil, ErrInvalidRefreshToken
+	}
+
+	// 用户被强制下线或租户被停用后不允许刷新
+	if t.revocation != nil {
+		revoked, err := t.revocation.IsRevoked(ctx, token, claims)
+		if err != nil {
+			logx.Errorw("failed to check token revocation", logx.Field("detail", err.Error()))
+			return claims, nil, status.Error(codes.Unavailable, "common.redisError")
+		}
+		if revoked {
+			return claims, nil, ErrTokenRevoked
+		}
+	}
+
+	return claims, mapClaims, nil
+}
+
+// revokeFamily 删除令牌族并吊销令牌族当前的访问令牌
+func (t *TokenIssuer) revokeFamily(ctx context.Context, familyId string) error {
+	family, err := t.store.Delete(ctx, familyId)
+	if err != nil {
+		if errors.Is(err, ErrRefreshFamilyNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if t.revocation != nil && family.AccessJti != common.EmptyString {
+		return t.revocation.RevokeToken(ctx, family.AccessJti, time.Duration(t.conf.AccessExpire)*time.Second)
+	}
+	return nil
+}
+
+func (t *TokenIssuer) sign(opts []jwt.Option, familyId, accessJti, refreshJti string) (*TokenPair, error) {
+	now := time.Now().Unix()
+
+	accessToken, err := jwt.NewJwtTokenWithProvider(t.provider, now, t.conf.AccessExpire, append(opts,
+		jwt.WithOption("jti", accessJti),
+		jwt.WithOption("tokenType", TokenTypeAccess),
+		jwt.WithOption("familyId", familyId))...)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := jwt.NewJwtTokenWithProvider(t.provider, now, t.conf.RefreshExpire, append(opts,
+		jwt.WithOption("jti", refreshJti),
+		jwt.WithOption("tokenType", TokenTypeRefresh),
+		jwt.WithOption("familyId", familyId))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:   accessToken,
+		AccessExpire:  now + t.conf.AccessExpire,
+		RefreshToken:  refreshToken,
+		RefreshExpire: now + t.conf.RefreshExpire,
+	}, nil
+}
+
+func (t *TokenIssuer) refreshTTL() time.Duration {
+	return time.Duration(t.conf.RefreshExpire) * time.Second
+}
+
+// claimsOptions 将 Claims 中的身份字段转换为 jwt 字段
+func claimsOptions(claims Claims) []jwt.Option {
+	opts := []jwt.Option{
+		jwt.WithOption("userId", claims.UserId),
+		jwt.WithOption("roleId", claims.RoleId),
+		jwt.WithOption("deptId", claims.DeptId),
+		jwt.WithOption("jwtTenantId", claims.TenantId),
+	}
+	if claims.ClientIp != common.EmptyString {
+		opts = append(opts, jwt.WithOption("clientIp", claims.ClientIp))
+	}
+	return opts
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/utils/jwt"
+)
+
+func TestTokenIssuerRotation(t *testing.T) {
+	ctx := context.Background()
+	provider := jwt.NewHMACKeyProvider(testSecret)
+	revocation := NewMemoryRevocationStore()
+	issuer := NewTokenIssuer(TokenIssuerConf{AccessExpire: 60, RefreshExpire: 3600}, provider,
+		NewMemoryRefreshTokenStore(), revocation)
+
+	pair, err := issuer.IssuePair(ctx, Claims{UserId: "u1", RoleId: "admin", DeptId: 3, TenantId: 2},
+		jwt.WithOption("nickname", "alice"))
+	assert.Nil(t, err)
+
+	claims, err := ValidateToken(pair.AccessToken, provider)
+	assert.Nil(t, err)
+	assert.Equal(t, "u1", claims.UserId)
+	assert.Equal(t, uint64(2), claims.TenantId)
+	assert.Equal(t, TokenTypeAccess, claims.TokenType)
+
+	// refresh tokens can not be used to authenticate
+	_, err = ValidateToken(pair.RefreshToken, provider)
+	assert.NotNil(t, err)
+
+	rotated, err := issuer.Refresh(ctx, pair.RefreshToken)
+	assert.Nil(t, err)
+	assert.NotEqual(t, pair.RefreshToken, rotated.RefreshToken)
+
+	rotatedMap, err := jwt.ParseJwtTokenWithProvider(rotated.AccessToken, provider)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", rotatedMap["nickname"])
+
+	rotatedClaims, err := ValidateToken(rotated.AccessToken, provider)
+	assert.Nil(t, err)
+	assert.Equal(t, claims.FamilyId, rotatedClaims.FamilyId)
+	assert.Equal(t, uint64(3), rotatedClaims.DeptId)
+
+	// replaying the old refresh token revokes the whole family
+	_, err = issuer.Refresh(ctx, pair.RefreshToken)
+	assert.Equal(t, ErrRefreshTokenReused, err)
+
+	revoked, _ := revocation.IsRevoked(ctx, rotated.AccessToken, rotatedClaims)
+	assert.True(t, revoked)
+
+	_, err = issuer.Refresh(ctx, rotated.RefreshToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+
+	_, err = issuer.Refresh(ctx, rotated.AccessToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
+
+func TestTokenIssuerRefreshKeepsLargeIds(t *testing.T) {
+	ctx := context.Background()
+	provider := jwt.NewHMACKeyProvider(testSecret)
+	issuer := NewTokenIssuer(TokenIssuerConf{AccessExpire: 60, RefreshExpire: 3600}, provider,
+		NewMemoryRefreshTokenStore(), NewMemoryRevocationStore())
+
+	const id = uint64(1<<60 + 1)
+	pair, err := issuer.IssuePair(ctx, Claims{UserId: "u1", RoleId: "admin", DeptId: id, TenantId: id},
+		jwt.WithOption("orgId", id))
+	assert.Nil(t, err)
+
+	rotated, err := issuer.Refresh(ctx, pair.RefreshToken)
+	assert.Nil(t, err)
+
+	claims, err := ValidateToken(rotated.AccessToken, provider)
+	assert.Nil(t, err)
+	assert.Equal(t, id, claims.DeptId)
+	assert.Equal(t, id, claims.TenantId)
+
+	rotatedMap, err := jwt.ParseJwtTokenWithProvider(rotated.AccessToken, provider)
+	assert.Nil(t, err)
+	assert.Equal(t, "1152921504606846977", fmt.Sprint(rotatedMap["orgId"]))
+}
+
+func TestTokenIssuerRevoke(t *testing.T) {
+	ctx := context.Background()
+	provider := jwt.NewHMACKeyProvider(testSecret)
+	revocation := NewMemoryRevocationStore()
+	issuer := NewTokenIssuer(TokenIssuerConf{AccessExpire: 60, RefreshExpire: 3600}, provider,
+		NewMemoryRefreshTokenStore(), revocation)
+
+	pair, err := issuer.IssuePair(ctx, Claims{UserId: "u1"})
+	assert.Nil(t, err)
+	assert.Nil(t, issuer.Revoke(ctx, pair.RefreshToken))
+
+	interceptor := UnaryAuthInterceptor(nil, provider, WithRevocationStore(revocation))
+	mdCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(Authorization, "Bearer "+pair.AccessToken))
+	_, err = interceptor(mdCtx, nil, &grpc.UnaryServerInfo{FullMethod: "/core.Core/GetUser"},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = issuer.Refresh(ctx, pair.RefreshToken)
+	assert.Equal(t, ErrInvalidRefreshToken, err)
+}
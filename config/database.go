@@ -19,13 +19,19 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/redis/go-redis/v9"
 	"github.com/zeromicro/go-zero/core/logx"
+	"mingyang.com/admin-common/orm/ent/dbdriver"
+	"mingyang.com/admin-common/pkg/bootstrap"
 )
 
 // DatabaseConf stores database configurations.
@@ -44,21 +50,209 @@ type DatabaseConf struct {
 	PGConfig     string `json:",optional,env=DATABASE_PG_CONFIG"`
 	SqliteConfig string `json:",optional,env=DATABASE_SQLITE_CONFIG"`
 	Debug        bool   `json:",optional,env=DATABASE_DEBUG"`
+	// MaxIdleConn is the max idle connections of the pool
+	MaxIdleConn int `json:",optional,default=10,env=DATABASE_MAX_IDLE_CONN"`
+	// ConnMaxLifetime is the max seconds a connection is reused, 0 means no limit
+	ConnMaxLifetime int `json:",optional,default=3600,env=DATABASE_CONN_MAX_LIFETIME"`
+	// ConnMaxIdleTime is the max seconds a connection is idle, 0 means no limit
+	ConnMaxIdleTime int `json:",optional,default=600,env=DATABASE_CONN_MAX_IDLE_TIME"`
+	// ReplicaDSN is the DSNs of the read replicas with the same Type, used by NewDriver
+	ReplicaDSN []string `json:",optional"`
+	// ReplicaCheckInterval is the interval seconds of the replica health check
+	ReplicaCheckInterval int `json:",optional,default=10,env=DATABASE_REPLICA_CHECK_INTERVAL"`
+	// TenantMaxDBs is the count of the isolated tenant databases kept open by NewTenantDriver
+	TenantMaxDBs int `json:",optional,default=32,env=DATABASE_TENANT_MAX_DBS"`
+	// TenantIdleTime is the idle seconds before the isolated tenant database is closed
+	TenantIdleTime int `json:",optional,default=600,env=DATABASE_TENANT_IDLE_TIME"`
+	// ConnectRetry is the retry times of connecting the database on startup
+	ConnectRetry int `json:",optional,default=3,env=DATABASE_CONNECT_RETRY"`
+	// ConnectRetryInterval is the seconds before the first retry, which is doubled for each retry
+	ConnectRetryInterval int `json:",optional,default=1,env=DATABASE_CONNECT_RETRY_INTERVAL"`
+	// StatsInterval is the interval seconds of reporting the pool stats metrics by RegisterDriver
+	StatsInterval int `json:",optional,default=15,env=DATABASE_STATS_INTERVAL"`
+	// SlowThreshold is the milliseconds of the slow statements logged by the Ent drivers, 0 disables the slow log
+	SlowThreshold int `json:",optional,default=500,env=DATABASE_SLOW_THRESHOLD"`
+	// ExplainSlowQuery logs the EXPLAIN plans of the slow read-only queries
+	ExplainSlowQuery bool `json:",optional,env=DATABASE_EXPLAIN_SLOW_QUERY"`
 }
 
-// NewNoCacheDriver returns an Ent device without cache.
-func (c DatabaseConf) NewNoCacheDriver() *entsql.Driver {
-	db, err := sql.Open(c.Type, c.GetDSN())
-	logx.Must(err)
+// NewDriver returns an Ent driver without cache, which sends the read-only queries to the replicas
+// if ReplicaDSN is set. Use dbdriver.WithPrimary to read the data just written and run the migration
+// on the primary.
+//
+// The statements are observed by the metrics and traces, the ones slower than SlowThreshold are logged,
+// and all statements are logged if Debug is true.
+func (c DatabaseConf) NewDriver() dialect.Driver {
+	primary := c.NewNoCacheDriver()
+	if len(c.ReplicaDSN) == 0 {
+		return c.observe(primary)
+	}
+
+	// the unreachable replicas do not stop the startup, they are skipped by the health check
+	replicas := make([]dialect.Driver, 0, len(c.ReplicaDSN))
+	for _, v := range c.ReplicaDSN {
+		drv, err := c.openPool(v)
+		logx.Must(err)
+		replicas = append(replicas, drv)
+	}
+	return c.observe(dbdriver.NewReplicaDriver(primary, replicas, time.Duration(c.ReplicaCheckInterval)*time.Second))
+}
+
+// observe returns the driver observing the statements by the slow log settings.
+func (c DatabaseConf) observe(drv dialect.Driver) dialect.Driver {
+	return dbdriver.NewObserveDriver(drv,
+		dbdriver.WithSlowThreshold(time.Duration(c.SlowThreshold)*time.Millisecond),
+		dbdriver.WithExplain(c.ExplainSlowQuery),
+		dbdriver.WithStatementLog(c.Debug))
+}
 
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	err = db.PingContext(ctx)
-	logx.Must(err)
+// NewCacheDriver returns an Ent driver of NewDriver which caches the query results for CacheTime seconds
+// in redis, or in memory if rds is nil. The cache is invalidated by the changes through the driver, and
+// dbdriver.WithoutCache reads the database directly.
+func (c DatabaseConf) NewCacheDriver(rds redis.UniversalClient) dialect.Driver {
+	var store dbdriver.CacheStore
+	if rds != nil {
+		store = dbdriver.NewRedisCacheStore(rds, RedisQueryCachePrefix)
+	} else {
+		store = dbdriver.NewMemoryCacheStore(0)
+	}
+	return dbdriver.NewCacheDriver(c.NewDriver(), store, time.Duration(c.CacheTime)*time.Second)
+}
 
+// NewTenantDriver returns an Ent driver which routes the tenants to the isolated databases or Postgres
+// schemas loaded from the registry, and the other tenants to NewDriver.
+func (c DatabaseConf) NewTenantDriver(registry dbdriver.TenantRegistry) dialect.Driver {
+	return dbdriver.NewTenantDriver(c.NewDriver(), registry, c.OpenTenantDriver,
+		dbdriver.WithMaxTenantDBs(c.TenantMaxDBs),
+		dbdriver.WithTenantIdleTime(time.Duration(c.TenantIdleTime)*time.Second))
+}
+
+// OpenTenantDriver returns the Ent driver of the tenant database, the empty DSN means the configured
+// database, and the schema is set as the search_path of Postgres.
+func (c DatabaseConf) OpenTenantDriver(db dbdriver.TenantDB) (dialect.Driver, error) {
+	dsn, err := c.tenantDSN(db)
+	if err != nil {
+		return nil, err
+	}
+
+	drv, err := c.openDriver(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return c.observe(drv), nil
+}
+
+func (c DatabaseConf) tenantDSN(db dbdriver.TenantDB) (string, error) {
+	dsn := db.DSN
+	if dsn == "" {
+		dsn = c.GetDSN()
+	}
+	if db.Schema == "" {
+		return dsn, nil
+	}
+
+	if c.Type != "postgres" {
+		return "", fmt.Errorf("the tenant schema is not supported by %s", c.Type)
+	}
+
+	// URL or key/value connection string
+	if !strings.Contains(dsn, "://") {
+		return fmt.Sprintf("%s search_path=%s", dsn, db.Schema), nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("search_path", db.Schema)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// mustOpenDriver returns the Ent driver of the DSN, and retries with backoff if the database is not ready.
+func (c DatabaseConf) mustOpenDriver(dsn string) *entsql.Driver {
+	interval := time.Duration(c.ConnectRetryInterval) * time.Second
+	for i := 0; ; i++ {
+		drv, err := c.openDriver(dsn)
+		if err == nil {
+			return drv
+		}
+		if i >= c.ConnectRetry {
+			logx.Must(err)
+		}
+
+		logx.Errorw("failed to connect to the database, retrying", logx.Field("retry", i+1),
+			logx.Field("interval", interval.String()), logx.Field("detail", err.Error()))
+		time.Sleep(interval)
+		interval = min(interval*2, 30*time.Second)
+	}
+}
+
+// openDriver returns the Ent driver of the DSN with the pool settings, and checks the connection.
+func (c DatabaseConf) openDriver(dsn string) (*entsql.Driver, error) {
+	drv, err := c.openPool(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err = drv.DB().PingContext(ctx); err != nil {
+		_ = drv.Close()
+		return nil, err
+	}
+	return drv, nil
+}
+
+// openPool returns the Ent driver of the DSN with the pool settings without connecting to the database.
+func (c DatabaseConf) openPool(dsn string) (*entsql.Driver, error) {
+	db, err := sql.Open(c.Type, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setPool(db)
+	return entsql.OpenDB(c.Type, db), nil
+}
+
+// setPool sets the pool settings, the zero values keep the defaults of database/sql.
+func (c DatabaseConf) setPool(db *sql.DB) {
 	db.SetMaxOpenConns(c.MaxOpenConn)
-	driver := entsql.OpenDB(c.Type, db)
+	if c.MaxIdleConn > 0 {
+		db.SetMaxIdleConns(c.MaxIdleConn)
+	}
+	if c.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(time.Duration(c.ConnMaxLifetime) * time.Second)
+	}
+	if c.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(time.Duration(c.ConnMaxIdleTime) * time.Second)
+	}
+}
+
+// NewNoCacheDriver returns an Ent device without cache, the connection is retried by ConnectRetry.
+func (c DatabaseConf) NewNoCacheDriver() *entsql.Driver {
+	return c.mustOpenDriver(c.GetDSN())
+}
+
+// RegisterDriver reports the pool stats of the driver as metrics, adds its primary pool to the health checks
+// and closes it on shutdown by the bootstrap lifecycle. The failed replicas and tenant databases are logged
+// as degraded by dbdriver.Ping instead of failing the health check.
+func (c DatabaseConf) RegisterDriver(name string, drv dialect.Driver) {
+	stop := dbdriver.ReportStats(name, drv, time.Duration(c.StatsInterval)*time.Second)
+	bootstrap.AddHealthCheck(name, func(ctx context.Context) error {
+		return dbdriver.Ping(ctx, drv)
+	})
+	bootstrap.AddCloser(name, closerFunc(func() error {
+		stop()
+		return drv.Close()
+	}))
+}
+
+type closerFunc func() error
 
-	return driver
+func (f closerFunc) Close() error {
+	return f()
 }
 
 // MysqlDSN returns mysql DSN.
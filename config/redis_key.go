@@ -20,6 +20,9 @@ const RedisCaptchaPrefix = "CAPTCHA:"
 // RedisTokenPrefix is the prefix of blacklist token key in redis
 const RedisTokenPrefix = "BLACKLIST:TOKEN:"
 
+// RedisRefreshTokenPrefix is the prefix of refresh token family key in redis
+const RedisRefreshTokenPrefix = "REFRESH:FAMILY:"
+
 // RedisTenantBlacklistPrefix is the prefix of tenant blacklist key in redis
 const RedisTenantBlacklistPrefix = "BLACKLIST:TENANT:"
 
@@ -34,3 +37,9 @@ const RedisDataPermissionPrefix = "DATAPERM:"
 
 // RedisDynamicConfigurationPrefix is the prefix of dynamic configuration in redis
 const RedisDynamicConfigurationPrefix = "CONFIGURATION:"
+
+// RedisSonyflakeWorkerPrefix is the prefix of sonyflake worker id lease in redis
+const RedisSonyflakeWorkerPrefix = "SONYFLAKE:WORKER:"
+
+// RedisQueryCachePrefix is the prefix of database query cache key in redis
+const RedisQueryCachePrefix = "DBCACHE:"
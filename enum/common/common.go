@@ -97,6 +97,11 @@ const (
 	HeaderTenantID  = "Tenant-Id"
 	HeaderXTenantID = "X-Tenant-ID"
 
+	// HeaderXGatewayTimestamp 网关签名时间戳(unix 秒)
+	HeaderXGatewayTimestamp = "X-Gateway-Timestamp"
+	// HeaderXGatewaySignature 网关对身份 Header 的 HMAC-SHA256 签名
+	HeaderXGatewaySignature = "X-Gateway-Signature"
+
 	HttpsPrefix = "https://"
 	HttpPrefix  = "http://"
 	WsPrefix    = "ws://"
@@ -105,6 +110,7 @@ const (
 	CtxKeyAppUserID   = "appUserId"
 	CtxKeyUserID      = "userId"
 	CtxKeyRoleID      = "roleId"
+	CtxKeyDeptID      = "deptId"
 	CtxKeyJwtTenantID = "jwtTenantId"
 
 	GET    = "GET"
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/enum/common"
+)
+
+const testGatewaySecret = "gateway-secret"
+
+func newGatewayRequest(method, target string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	r.Header.Set(common.HeaderXUserID, "u1")
+	r.Header.Set(common.HeaderXRoleID, "admin")
+	r.Header.Set(common.HeaderXTenantID, "2")
+	SignGatewayHeaders(r, testGatewaySecret)
+	return r
+}
+
+func TestVerifyGatewayHeaders(t *testing.T) {
+	r := newGatewayRequest(http.MethodGet, "/user/info?id=1")
+	assert.Nil(t, VerifyGatewayHeaders(r, testGatewaySecret, time.Minute))
+	assert.Equal(t, ErrGatewaySignatureInvalid, VerifyGatewayHeaders(r, "other", time.Minute))
+
+	// the signature is bound to the identity headers
+	forged := r.Clone(r.Context())
+	forged.Header.Set(common.HeaderXRoleID, "superadmin")
+	assert.Equal(t, ErrGatewaySignatureInvalid, VerifyGatewayHeaders(forged, testGatewaySecret, time.Minute))
+
+	// the signature can not be replayed on other methods and paths
+	forged = r.Clone(r.Context())
+	forged.Method = http.MethodDelete
+	assert.Equal(t, ErrGatewaySignatureInvalid, VerifyGatewayHeaders(forged, testGatewaySecret, time.Minute))
+	forged = r.Clone(r.Context())
+	forged.URL.Path = "/user/delete"
+	assert.Equal(t, ErrGatewaySignatureInvalid, VerifyGatewayHeaders(forged, testGatewaySecret, time.Minute))
+
+	expired := newGatewayRequest(http.MethodGet, "/user/info")
+	expired.Header.Set(common.HeaderXGatewayTimestamp,
+		strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), common.Ten))
+	assert.Equal(t, ErrGatewaySignatureExpired, VerifyGatewayHeaders(expired, testGatewaySecret, time.Minute))
+
+	assert.Equal(t, ErrGatewaySignatureMissing, VerifyGatewayHeaders(httptest.NewRequest(http.MethodGet, "/", nil),
+		testGatewaySecret, time.Minute))
+}
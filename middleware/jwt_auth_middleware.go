@@ -0,0 +1,267 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/errorx"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/auth"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/utils/jwt"
+)
+
+// iatLeeway 签发时间允许超前的时钟偏差
+const iatLeeway = time.Minute
+
+// JwtAuthMiddleware HTTP 认证中间件, 与 auth.UnaryAuthInterceptor 对应。
+//
+// 校验 Authorization 中 Bearer token 的签名、exp / iat 与 clientIp, 通过后将身份信息写入
+// 与 RPC 拦截器相同的 context key, 并追加到 outgoing gRPC metadata 供下游 RPC 使用。
+// 配置 WithTrustedGateway 后, 带有网关签名的请求直接使用 X-User-ID 等 Header 中的身份信息。
+//
+// 客户端 IP 默认为连接地址, 位于网关或负载均衡之后时通过 WithTrustedProxies 配置可信代理。
+type JwtAuthMiddleware struct {
+	provider       jwt.KeyProvider
+	revocation     auth.RevocationStore
+	gatewaySecret  string
+	gatewayMaxSkew time.Duration
+	trustedProxies []netip.Prefix
+}
+
+// JwtAuthOption HTTP 认证中间件可选项
+type JwtAuthOption func(*JwtAuthMiddleware)
+
+// WithJwtRevocationStore 设置吊销存储, 校验签名后再检查 token 是否已被吊销
+func WithJwtRevocationStore(store auth.RevocationStore) JwtAuthOption {
+	return func(m *JwtAuthMiddleware) {
+		m.revocation = store
+	}
+}
+
+// WithTrustedGateway 信任带有网关签名的身份 Header, secret 为与网关共享的签名密钥,
+// maxSkew 为签名时间戳允许的最大偏差, 为 0 时使用默认 30 秒
+func WithTrustedGateway(secret string, maxSkew time.Duration) JwtAuthOption {
+	return func(m *JwtAuthMiddleware) {
+		if maxSkew <= 0 {
+			maxSkew = 30 * time.Second
+		}
+		m.gatewaySecret = secret
+		m.gatewayMaxSkew = maxSkew
+	}
+}
+
+// WithTrustedProxies 设置可信代理的 IP 或 CIDR, 连接地址为可信代理时才读取 X-Forwarded-For 与 X-Real-IP,
+// 取 X-Forwarded-For 中从右往左第一个不可信的地址作为客户端 IP, 地址格式错误时退出
+func WithTrustedProxies(proxies ...string) JwtAuthOption {
+	return func(m *JwtAuthMiddleware) {
+		for _, v := range proxies {
+			prefix, err := parsePrefix(v)
+			logx.Must(err)
+			m.trustedProxies = append(m.trustedProxies, prefix)
+		}
+	}
+}
+
+// NewJwtAuthMiddleware 创建 HTTP 认证中间件, 使用共享密钥时传入 jwt.NewHMACKeyProvider(secretKey)
+func NewJwtAuthMiddleware(provider jwt.KeyProvider, opts ...JwtAuthOption) *JwtAuthMiddleware {
+	m := &JwtAuthMiddleware{provider: provider}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func (m *JwtAuthMiddleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := jwt.StripBearerPrefixFromToken(r.Header.Get(auth.Authorization))
+
+		var claims auth.Claims
+		var err error
+		// 网关已完成 token 校验与吊销检查, 带签名的请求直接信任身份 Header
+		viaGateway := m.gatewaySecret != common.EmptyString && r.Header.Get(common.HeaderXGatewaySignature) != common.EmptyString
+		if viaGateway {
+			claims, err = m.gatewayClaims(r)
+		} else {
+			claims, err = m.tokenClaims(r, token)
+		}
+		if err != nil {
+			logx.WithContext(r.Context()).Errorw("unauthorized request", logx.Field("path", r.URL.Path),
+				logx.Field("detail", err.Error()))
+			httpx.ErrorCtx(r.Context(), w, errorx.NewApiUnauthorizedError("auth.unauthorized"))
+			return
+		}
+
+		if m.revocation != nil && !viaGateway {
+			revoked, err := m.revocation.IsRevoked(r.Context(), token, claims)
+			if err != nil {
+				logx.WithContext(r.Context()).Errorw("failed to check token revocation", logx.Field("detail", err.Error()))
+				httpx.ErrorCtx(r.Context(), w, errorx.NewApiError(http.StatusServiceUnavailable, "common.redisError"))
+				return
+			}
+			if revoked {
+				httpx.ErrorCtx(r.Context(), w, errorx.NewApiUnauthorizedError("auth.tokenRevoked"))
+				return
+			}
+		}
+
+		next(w, r.WithContext(newOutgoingContext(auth.NewContextWithClaims(r.Context(), claims), claims, token)))
+	}
+}
+
+// JwtAuth 返回可直接用于 rest.Server.Use 的中间件函数:
+//
+//	server.Use(middleware.JwtAuth(jwt.NewHMACKeyProvider(c.Auth.AccessSecret)))
+func JwtAuth(provider jwt.KeyProvider, opts ...JwtAuthOption) rest.Middleware {
+	return NewJwtAuthMiddleware(provider, opts...).Handle
+}
+
+// tokenClaims 校验 Bearer token 并返回 Claims
+func (m *JwtAuthMiddleware) tokenClaims(r *http.Request, token string) (auth.Claims, error) {
+	if token == common.EmptyString {
+		return auth.Claims{}, errors.New("token is empty")
+	}
+
+	claims, err := auth.ValidateToken(token, m.provider)
+	if err != nil {
+		return claims, err
+	}
+
+	// 签名校验只在 exp 存在时检查过期, 这里要求必须有 exp 且 iat 不能晚于当前时间
+	now := time.Now()
+	if claims.Exp == common.Zero || now.Unix() >= claims.Exp {
+		return claims, errors.New("token is expired")
+	}
+	if claims.Iat > now.Add(iatLeeway).Unix() {
+		return claims, errors.New("token is issued in the future")
+	}
+
+	if claims.ClientIp != common.EmptyString && claims.ClientIp != m.clientIP(r) {
+		return claims, errors.New("client ip mismatch")
+	}
+
+	return claims, nil
+}
+
+// gatewayClaims 校验网关签名并从身份 Header 中读取 Claims
+func (m *JwtAuthMiddleware) gatewayClaims(r *http.Request) (auth.Claims, error) {
+	claims := auth.Claims{
+		UserId:   r.Header.Get(common.HeaderXUserID),
+		RoleId:   r.Header.Get(common.HeaderXRoleID),
+		ClientIp: m.clientIP(r),
+	}
+	if err := VerifyGatewayHeaders(r, m.gatewaySecret, m.gatewayMaxSkew); err != nil {
+		return claims, err
+	}
+
+	var err error
+	if deptId := r.Header.Get(common.HeaderXDeptID); deptId != common.EmptyString {
+		if claims.DeptId, err = strconv.ParseUint(deptId, common.Ten, 64); err != nil {
+			return claims, err
+		}
+	}
+	if tenantId := r.Header.Get(common.HeaderXTenantID); tenantId != common.EmptyString {
+		if claims.TenantId, err = strconv.ParseUint(tenantId, common.Ten, 64); err != nil {
+			return claims, err
+		}
+	}
+
+	return claims, nil
+}
+
+// newOutgoingContext 将身份信息与原始 token 追加到 outgoing gRPC metadata
+func newOutgoingContext(ctx context.Context, claims auth.Claims, token string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	setMetadata(md, enum.UserIdRpcCtxKey, claims.UserId)
+	setMetadata(md, enum.RoleIdRpcCtxKey, claims.RoleId)
+	if claims.DeptId != common.Zero {
+		md.Set(enum.DepartmentIdRpcCtxKey, strconv.FormatUint(claims.DeptId, common.Ten))
+	}
+	if claims.TenantId != common.Zero {
+		md.Set(enum.TenantIdCtxKey, strconv.FormatUint(claims.TenantId, common.Ten))
+	}
+	setMetadata(md, enum.ClientIPCtxKey, claims.ClientIp)
+	if token != common.EmptyString {
+		md.Set(auth.Authorization, token)
+	}
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func setMetadata(md metadata.MD, key, val string) {
+	if val != common.EmptyString {
+		md.Set(key, val)
+	}
+}
+
+// clientIP 返回客户端 IP, 连接地址不是可信代理时直接返回连接地址, 否则依次使用 X-Forwarded-For 中
+// 从右往左第一个不可信的地址与 X-Real-IP, 伪造的 X-Forwarded-For 只能出现在可信代理追加的地址之前
+func (m *JwtAuthMiddleware) clientIP(r *http.Request) string {
+	peer, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		peer = r.RemoteAddr
+	}
+	if !m.trusted(peer) {
+		return peer
+	}
+
+	var forwarded []string
+	for _, v := range r.Header.Values(common.XForwardedFor) {
+		for _, ip := range strings.Split(v, common.Comma) {
+			if ip = strings.TrimSpace(ip); ip != common.EmptyString {
+				forwarded = append(forwarded, ip)
+			}
+		}
+	}
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		if !m.trusted(forwarded[i]) || i == 0 {
+			return forwarded[i]
+		}
+	}
+
+	if ip := strings.TrimSpace(r.Header.Get(common.XRealIP)); ip != common.EmptyString {
+		return ip
+	}
+	return peer
+}
+
+// trusted 返回 ip 是否属于可信代理
+func (m *JwtAuthMiddleware) trusted(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, v := range m.trustedProxies {
+		if v.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePrefix 解析 IP 或 CIDR, 单个 IP 视为只包含该地址的网段
+func parsePrefix(v string) (netip.Prefix, error) {
+	if strings.Contains(v, "/") {
+		prefix, err := netip.ParsePrefix(v)
+		return prefix.Masked(), err
+	}
+
+	addr, err := netip.ParseAddr(v)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
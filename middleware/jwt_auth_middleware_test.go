@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/auth"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/utils/jwt"
+)
+
+const testSecret = "jS6VKDtsJf3z1n2VKDtsJf3z1n2"
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{name: "no proxy", remoteAddr: "1.1.1.1:1234", forwarded: []string{"9.9.9.9"}, realIP: "8.8.8.8",
+			want: "1.1.1.1"},
+		{name: "untrusted peer", proxies: []string{"10.0.0.0/8"}, remoteAddr: "1.1.1.1:1234",
+			forwarded: []string{"9.9.9.9"}, want: "1.1.1.1"},
+		{name: "trusted peer", proxies: []string{"10.0.0.0/8"}, remoteAddr: "10.0.0.2:1234",
+			forwarded: []string{"2.2.2.2"}, want: "2.2.2.2"},
+		{name: "forged forwarded", proxies: []string{"10.0.0.0/8"}, remoteAddr: "10.0.0.2:1234",
+			forwarded: []string{"9.9.9.9, 2.2.2.2", "10.0.0.3"}, want: "2.2.2.2"},
+		{name: "all trusted", proxies: []string{"10.0.0.0/8", "127.0.0.1"}, remoteAddr: "127.0.0.1:1234",
+			forwarded: []string{"10.0.0.4, 10.0.0.3"}, want: "10.0.0.4"},
+		{name: "real ip", proxies: []string{"::1"}, remoteAddr: "[::1]:1234", realIP: "2.2.2.2",
+			want: "2.2.2.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewJwtAuthMiddleware(jwt.NewHMACKeyProvider(testSecret), WithTrustedProxies(tt.proxies...))
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.forwarded {
+				r.Header.Add(common.XForwardedFor, v)
+			}
+			if tt.realIP != common.EmptyString {
+				r.Header.Set(common.XRealIP, tt.realIP)
+			}
+			assert.Equal(t, tt.want, m.clientIP(r))
+		})
+	}
+}
+
+func TestJwtAuthMiddleware(t *testing.T) {
+	var claims auth.Claims
+	var md metadata.MD
+	handler := JwtAuth(jwt.NewHMACKeyProvider(testSecret), WithTrustedGateway(testGatewaySecret, 0),
+		WithTrustedProxies("10.0.0.0/8"))(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ = auth.ClaimsFromContext(r.Context())
+		md, _ = metadata.FromOutgoingContext(r.Context())
+	})
+	serve := func(r *http.Request) int {
+		claims, md = auth.Claims{}, nil
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w.Code
+	}
+
+	token, err := jwt.NewJwtToken(testSecret, time.Now().Unix(), 3600, jwt.WithOption("userId", "u1"),
+		jwt.WithOption("roleId", "admin"), jwt.WithOption("clientIp", "2.2.2.2"))
+	assert.Nil(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/user/info", nil)
+	r.RemoteAddr = "10.0.0.2:1234"
+	r.Header.Set(common.XForwardedFor, "2.2.2.2")
+	r.Header.Set(auth.Authorization, "Bearer "+token)
+	assert.Equal(t, http.StatusOK, serve(r))
+	assert.Equal(t, "u1", claims.UserId)
+	assert.Equal(t, []string{"u1"}, md.Get(enum.UserIdRpcCtxKey))
+	assert.Equal(t, []string{token}, md.Get(auth.Authorization))
+
+	// the client ip of the token can not be forged by the header out of the trusted proxies
+	r = httptest.NewRequest(http.MethodGet, "/user/info", nil)
+	r.RemoteAddr = "3.3.3.3:1234"
+	r.Header.Set(common.XForwardedFor, "2.2.2.2")
+	r.Header.Set(auth.Authorization, "Bearer "+token)
+	assert.Equal(t, http.StatusUnauthorized, serve(r))
+
+	r = httptest.NewRequest(http.MethodGet, "/user/info", nil)
+	assert.Equal(t, http.StatusUnauthorized, serve(r))
+
+	// the gateway signed identity headers
+	r = newGatewayRequest(http.MethodGet, "/user/info")
+	r.RemoteAddr = "10.0.0.2:1234"
+	r.Header.Set(common.XForwardedFor, "2.2.2.2")
+	assert.Equal(t, http.StatusOK, serve(r))
+	assert.Equal(t, auth.Claims{UserId: "u1", RoleId: "admin", TenantId: 2, ClientIp: "2.2.2.2"}, claims)
+	assert.Empty(t, md.Get(auth.Authorization))
+
+	r = newGatewayRequest(http.MethodGet, "/user/info")
+	r.URL.Path = "/user/delete"
+	assert.Equal(t, http.StatusUnauthorized, serve(r))
+}
@@ -17,6 +17,7 @@ import (
 // 同时追加到 outgoing gRPC metadata, 供下游 RPC 使用。
 //
 // 适用于位于网关之后的各业务 API 服务, 通过 server.Use(...) 全局注册。
+// Header 未经校验, 服务可绕过网关直接访问时应使用 JwtAuthMiddleware 及其 WithTrustedGateway 模式。
 type UserContextMiddleware struct{}
 
 func NewUserContextMiddleware() *UserContextMiddleware {
@@ -0,0 +1,577 @@
+package dbdriver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/collection"
+	"github.com/zeromicro/go-zero/core/logx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+// DefaultMemoryCacheLimit is the default count of the query results kept by MemoryCacheStore.
+const DefaultMemoryCacheLimit = 10000
+
+type noCacheKey struct{}
+
+// WithoutCache returns context which reads the data from the database instead of the cache.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+func isNoCache(ctx context.Context) bool {
+	noCache, _ := ctx.Value(noCacheKey{}).(bool)
+	return noCache
+}
+
+// tablePattern matches the table names of the statements, the names may be quoted by ` or ".
+var tablePattern = regexp.MustCompile("(?i)\\b(?:FROM|JOIN|INTO|UPDATE)\\s+[`\"]?([\\w.]+)[`\"]?")
+
+// queryTables returns the table names in the query.
+func queryTables(query string) []string {
+	var tables []string
+	for _, v := range tablePattern.FindAllStringSubmatch(query, -1) {
+		name := strings.ToLower(v[1])
+		if !containsString(tables, name) {
+			tables = append(tables, name)
+		}
+	}
+	return tables
+}
+
+// catalogPrefixes are the system tables of MySQL, Postgres and SQLite.
+var catalogPrefixes = []string{"information_schema", "performance_schema", "mysql.", "pg_", "sqlite_", "pragma_"}
+
+// isCatalogQuery returns true if the query reads the system tables, such as the migration, or no table.
+// The migration expects the rows of database/sql, which are not cached.
+func isCatalogQuery(tables []string) bool {
+	if len(tables) == 0 {
+		return true
+	}
+
+	for _, v := range tables {
+		for _, prefix := range catalogPrefixes {
+			if strings.HasPrefix(v, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheStore stores the query results of CacheDriver.
+type CacheStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Versions returns the versions of the tables, which are increased by Invalidate.
+	Versions(ctx context.Context, tables []string) ([]uint64, error)
+	// Invalidate increases the versions of the tables, so the cached results of them are not read again.
+	Invalidate(ctx context.Context, tables ...string) error
+}
+
+// CacheDriver caches the results of the read-only queries out of the transactions for ttl. The cache key
+// contains the query, args, tenant and the versions of the tables in the query, and the versions are increased
+// when the statements and transactions through the driver change the tables. The changes made by other
+// applications are read after ttl, use WithoutCache to read them immediately.
+type CacheDriver struct {
+	dialect.Driver
+	store CacheStore
+	ttl   time.Duration
+}
+
+// NewCacheDriver returns the driver caching the query results in the store.
+func NewCacheDriver(drv dialect.Driver, store CacheStore, ttl time.Duration) *CacheDriver {
+	return &CacheDriver{Driver: drv, store: store, ttl: ttl}
+}
+
+// Query reads the result from the cache or the driver for the read-only queries.
+func (d *CacheDriver) Query(ctx context.Context, query string, args, v any) error {
+	if !isReadOnly(query) {
+		if err := d.Driver.Query(ctx, query, args, v); err != nil {
+			return err
+		}
+		d.invalidate(ctx, query)
+		return nil
+	}
+
+	rows, ok := v.(*entsql.Rows)
+	tables := queryTables(query)
+	if !ok || d.ttl <= 0 || isNoCache(ctx) || isCatalogQuery(tables) {
+		return d.Driver.Query(ctx, query, args, v)
+	}
+
+	key, err := d.cacheKey(ctx, query, args, tables)
+	if err != nil {
+		logx.Errorw("failed to get query cache key", logx.Field("detail", err.Error()))
+		return d.Driver.Query(ctx, query, args, v)
+	}
+
+	if b, ok, err := d.store.Get(ctx, key); err != nil {
+		logx.Errorw("failed to get query cache", logx.Field("detail", err.Error()))
+	} else if ok {
+		result := &cachedResult{}
+		if err = gob.NewDecoder(bytes.NewReader(b)).Decode(result); err == nil {
+			*rows = entsql.Rows{ColumnScanner: &cachedRows{result: result}}
+			return nil
+		}
+		logx.Errorw("failed to decode query cache", logx.Field("detail", err.Error()))
+	}
+
+	if err = d.Driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	result, err := readRows(rows)
+	if err != nil {
+		return err
+	}
+	*rows = entsql.Rows{ColumnScanner: &cachedRows{result: result}}
+
+	var buf bytes.Buffer
+	if err = gob.NewEncoder(&buf).Encode(result); err != nil {
+		logx.Errorw("failed to encode query cache", logx.Field("detail", err.Error()))
+		return nil
+	}
+	if err = d.store.Set(ctx, key, buf.Bytes(), d.ttl); err != nil {
+		logx.Errorw("failed to set query cache", logx.Field("detail", err.Error()))
+	}
+	return nil
+}
+
+// Exec executes the statement and invalidates the cache of the changed tables.
+func (d *CacheDriver) Exec(ctx context.Context, query string, args, v any) error {
+	if err := d.Driver.Exec(ctx, query, args, v); err != nil {
+		return err
+	}
+	d.invalidate(ctx, query)
+	return nil
+}
+
+// Tx starts a transaction, which invalidates the cache of the changed tables on commit.
+func (d *CacheDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheTx{Tx: tx, driver: d}, nil
+}
+
+// BeginTx starts a transaction with options, which invalidates the cache of the changed tables on commit.
+func (d *CacheDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support BeginTx")
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheTx{Tx: tx, driver: d}, nil
+}
+
+// invalidate increases the versions of the tables in the write statement.
+func (d *CacheDriver) invalidate(ctx context.Context, query string) {
+	d.invalidateTables(ctx, queryTables(query))
+}
+
+func (d *CacheDriver) invalidateTables(ctx context.Context, tables []string) {
+	if len(tables) == 0 {
+		return
+	}
+
+	if err := d.store.Invalidate(context.WithoutCancel(ctx), tables...); err != nil {
+		logx.Errorw("failed to invalidate query cache", logx.Field("tables", tables),
+			logx.Field("detail", err.Error()))
+	}
+}
+
+// cacheKey returns the hash of the tenant, query, args and the versions of the tables.
+func (d *CacheDriver) cacheKey(ctx context.Context, query string, args any, tables []string) (string, error) {
+	versions, err := d.store.Versions(ctx, tables)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%s\n%s\n%v", tenantctx.GetTenantIDFromCtx(ctx), query, b, versions)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cacheTx records the tables changed in the transaction, and invalidates them on commit.
+type cacheTx struct {
+	dialect.Tx
+	driver *CacheDriver
+
+	mu      sync.Mutex
+	changed []string
+}
+
+func (tx *cacheTx) Exec(ctx context.Context, query string, args, v any) error {
+	if err := tx.Tx.Exec(ctx, query, args, v); err != nil {
+		return err
+	}
+	tx.record(query)
+	return nil
+}
+
+func (tx *cacheTx) Query(ctx context.Context, query string, args, v any) error {
+	if err := tx.Tx.Query(ctx, query, args, v); err != nil {
+		return err
+	}
+	if !isReadOnly(query) {
+		tx.record(query)
+	}
+	return nil
+}
+
+func (tx *cacheTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := txExecContext(ctx, tx.Tx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	tx.record(query)
+	return res, nil
+}
+
+func (tx *cacheTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	rows, err := txQueryContext(ctx, tx.Tx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if !isReadOnly(query) {
+		tx.record(query)
+	}
+	return rows, nil
+}
+
+func (tx *cacheTx) Commit() error {
+	if err := tx.Tx.Commit(); err != nil {
+		return err
+	}
+
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+	tx.driver.invalidateTables(context.Background(), tx.changed)
+	return nil
+}
+
+func (tx *cacheTx) record(query string) {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	for _, v := range queryTables(query) {
+		if !containsString(tx.changed, v) {
+			tx.changed = append(tx.changed, v)
+		}
+	}
+}
+
+func init() {
+	gob.Register(time.Time{})
+}
+
+// cachedResult is the columns and values of the query result.
+type cachedResult struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// readRows reads and closes the rows.
+func readRows(rows *entsql.Rows) (*cachedResult, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cachedResult{Columns: columns}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	return result, rows.Err()
+}
+
+// cachedRows implements entsql.ColumnScanner by the cached result.
+type cachedRows struct {
+	result *cachedResult
+	i      int
+}
+
+func (r *cachedRows) Close() error {
+	return nil
+}
+
+func (r *cachedRows) ColumnTypes() ([]*sql.ColumnType, error) {
+	return nil, errors.New("dbdriver: column types are not cached")
+}
+
+func (r *cachedRows) Columns() ([]string, error) {
+	return r.result.Columns, nil
+}
+
+func (r *cachedRows) Err() error {
+	return nil
+}
+
+func (r *cachedRows) Next() bool {
+	if r.i >= len(r.result.Rows) {
+		return false
+	}
+	r.i++
+	return true
+}
+
+func (r *cachedRows) NextResultSet() bool {
+	return false
+}
+
+func (r *cachedRows) Scan(dest ...any) error {
+	if r.i == 0 || r.i > len(r.result.Rows) {
+		return errors.New("dbdriver: Scan called without calling Next")
+	}
+
+	row := r.result.Rows[r.i-1]
+	if len(dest) != len(row) {
+		return fmt.Errorf("dbdriver: expected %d destination arguments in Scan, not %d", len(row), len(dest))
+	}
+	for i, v := range row {
+		if err := assignValue(dest[i], v); err != nil {
+			return fmt.Errorf("dbdriver: converting column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// assignValue assigns the driver value to the scan destination, the scanners such as sql.NullString
+// convert the value in the same way as database/sql.
+func assignValue(dest, v any) error {
+	if b, ok := v.([]byte); ok {
+		// the scanners may keep the bytes
+		v = bytes.Clone(b)
+	}
+
+	switch d := dest.(type) {
+	case sql.Scanner:
+		return d.Scan(v)
+	case *any:
+		*d = v
+		return nil
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("destination not a pointer: %T", dest)
+	}
+	elem := rv.Elem()
+
+	var (
+		scanner sql.Scanner
+		value   func() reflect.Value
+	)
+	switch elem.Kind() {
+	case reflect.String:
+		n := &sql.NullString{}
+		scanner, value = n, func() reflect.Value { return reflect.ValueOf(n.String) }
+	case reflect.Bool:
+		n := &sql.NullBool{}
+		scanner, value = n, func() reflect.Value { return reflect.ValueOf(n.Bool) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := &sql.NullInt64{}
+		scanner, value = n, func() reflect.Value { return reflect.ValueOf(n.Int64) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := &sql.NullString{}
+		scanner, value = n, func() reflect.Value {
+			u, _ := strconv.ParseUint(n.String, 10, 64)
+			return reflect.ValueOf(u)
+		}
+	case reflect.Float32, reflect.Float64:
+		n := &sql.NullFloat64{}
+		scanner, value = n, func() reflect.Value { return reflect.ValueOf(n.Float64) }
+	case reflect.Slice:
+		if elem.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported destination type %T", dest)
+		}
+		if v == nil {
+			elem.SetZero()
+			return nil
+		}
+		n := &sql.NullString{}
+		scanner, value = n, func() reflect.Value { return reflect.ValueOf([]byte(n.String)) }
+	case reflect.Struct:
+		if _, ok := elem.Interface().(time.Time); !ok {
+			return fmt.Errorf("unsupported destination type %T", dest)
+		}
+		n := &sql.NullTime{}
+		scanner, value = n, func() reflect.Value { return reflect.ValueOf(n.Time) }
+	default:
+		return fmt.Errorf("unsupported destination type %T", dest)
+	}
+
+	if v == nil {
+		return fmt.Errorf("converting NULL to %s is unsupported", elem.Kind())
+	}
+	if err := scanner.Scan(v); err != nil {
+		return err
+	}
+	elem.Set(value().Convert(elem.Type()))
+	return nil
+}
+
+// MemoryCacheStore stores the query results in memory, the invalidation is not shared by the processes.
+type MemoryCacheStore struct {
+	cache *collection.Cache
+
+	mu       sync.Mutex
+	versions map[string]uint64
+}
+
+// NewMemoryCacheStore returns the store keeping limit results at most, DefaultMemoryCacheLimit is used
+// if limit <= 0.
+func NewMemoryCacheStore(limit int) *MemoryCacheStore {
+	if limit <= 0 {
+		limit = DefaultMemoryCacheLimit
+	}
+
+	// the expiry of each result is set by Set
+	cache, err := collection.NewCache(time.Minute, collection.WithLimit(limit))
+	logx.Must(err)
+	return &MemoryCacheStore{cache: cache, versions: map[string]uint64{}}
+}
+
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	v, ok := s.cache.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	return v.([]byte), true, nil
+}
+
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.cache.SetWithExpire(key, value, ttl)
+	return nil
+}
+
+func (s *MemoryCacheStore) Versions(_ context.Context, tables []string) ([]uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	versions := make([]uint64, len(tables))
+	for i, v := range tables {
+		versions[i] = s.versions[v]
+	}
+	return versions, nil
+}
+
+func (s *MemoryCacheStore) Invalidate(_ context.Context, tables ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range tables {
+		s.versions[v]++
+	}
+	return nil
+}
+
+// RedisCacheStore stores the query results in redis, the invalidation is shared by the processes.
+type RedisCacheStore struct {
+	rds    redis.UniversalClient
+	prefix string
+}
+
+// NewRedisCacheStore returns the store with the key prefix, such as config.RedisQueryCachePrefix.
+func NewRedisCacheStore(rds redis.UniversalClient, prefix string) *RedisCacheStore {
+	return &RedisCacheStore{rds: rds, prefix: prefix}
+}
+
+func (s *RedisCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b, err := s.rds.Get(ctx, s.prefix+"RESULT:"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+func (s *RedisCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.rds.Set(ctx, s.prefix+"RESULT:"+key, value, ttl).Err()
+}
+
+func (s *RedisCacheStore) Versions(ctx context.Context, tables []string) ([]uint64, error) {
+	if len(tables) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(tables))
+	for i, v := range tables {
+		keys[i] = s.tableKey(v)
+	}
+	values, err := s.rds.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]uint64, len(tables))
+	for i, v := range values {
+		if str, ok := v.(string); ok {
+			versions[i], _ = strconv.ParseUint(str, 10, 64)
+		}
+	}
+	return versions, nil
+}
+
+func (s *RedisCacheStore) Invalidate(ctx context.Context, tables ...string) error {
+	_, err := s.rds.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, v := range tables {
+			pipe.Incr(ctx, s.tableKey(v))
+		}
+		return nil
+	})
+	return err
+}
+
+// tableKey returns the version key of the table, the hash tag keeps the keys in one slot for MGET of cluster.
+func (s *RedisCacheStore) tableKey(table string) string {
+	return s.prefix + "{TABLE}:" + table
+}
@@ -0,0 +1,116 @@
+package dbdriver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+// countDriver counts the queries sent to the database.
+type countDriver struct {
+	dialect.Driver
+	queries int
+}
+
+func (d *countDriver) Query(ctx context.Context, query string, args, v any) error {
+	d.queries++
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+func TestCacheDriver(t *testing.T) {
+	ctx := tenantctx.WithTenantId(context.Background(), 1)
+	db := &countDriver{Driver: openTestDB(t, "cache", "a")}
+	drv := NewCacheDriver(db, NewMemoryCacheStore(0), time.Minute)
+	defer drv.Close()
+
+	query := "SELECT `name` FROM `items` WHERE `name` = ?"
+	assert.Equal(t, "a", queryName(t, ctx, drv, query, "a"))
+	assert.Equal(t, "a", queryName(t, ctx, drv, query, "a"))
+	assert.Equal(t, 1, db.queries)
+
+	// the key contains args and tenant
+	rows := &entsql.Rows{}
+	assert.Nil(t, drv.Query(ctx, "SELECT `name` FROM `items` WHERE `name` = ?", []any{"b"}, rows))
+	assert.False(t, rows.Next())
+	assert.Nil(t, rows.Close())
+	assert.Equal(t, 2, db.queries)
+	queryName(t, tenantctx.WithTenantId(ctx, 2), drv, query, "a")
+	assert.Equal(t, 3, db.queries)
+	queryName(t, WithoutCache(ctx), drv, query, "a")
+	assert.Equal(t, 4, db.queries)
+
+	// the write invalidates the table
+	assert.Nil(t, drv.Exec(ctx, "UPDATE `items` SET `name` = 'b'", []any{}, nil))
+	rows = &entsql.Rows{}
+	assert.Nil(t, drv.Query(ctx, query, []any{"a"}, rows))
+	assert.False(t, rows.Next())
+	assert.Nil(t, rows.Close())
+	assert.Equal(t, 5, db.queries)
+
+	// the transaction invalidates the table on commit
+	count := "SELECT COUNT(*) FROM \"items\""
+	assert.Equal(t, "1", queryName(t, ctx, drv, count))
+	tx, err := drv.Tx(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Exec(ctx, "INSERT INTO \"items\" (\"name\") VALUES (?)", []any{"c"}, nil))
+	assert.Equal(t, "1", queryName(t, ctx, drv, count))
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, "2", queryName(t, ctx, drv, count))
+
+	// the statements executed by ExecContext are also recorded
+	tx, err = drv.Tx(ctx)
+	assert.Nil(t, err)
+	_, err = tx.(*cacheTx).ExecContext(ctx, "INSERT INTO \"items\" (\"name\") VALUES (?)", "d")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, "3", queryName(t, ctx, drv, count))
+}
+
+func TestQueryTables(t *testing.T) {
+	assert.Equal(t, []string{"users", "roles"},
+		queryTables("SELECT * FROM `users` JOIN `roles` ON `users`.`role_id` = `roles`.`id`"))
+	assert.Equal(t, []string{"users"}, queryTables("INSERT INTO \"users\" (\"name\") VALUES ($1) RETURNING \"id\""))
+	assert.Equal(t, []string{"users"}, queryTables("UPDATE users SET name = ?"))
+	assert.Equal(t, []string{"users"}, queryTables("DELETE FROM `users` WHERE `id` IN (SELECT id FROM users)"))
+
+	assert.True(t, isCatalogQuery(queryTables("SELECT * FROM `INFORMATION_SCHEMA`.`COLUMNS` WHERE `TABLE_NAME` = ?")))
+	assert.True(t, isCatalogQuery(queryTables("SELECT name, file from pragma_database_list()")))
+	assert.True(t, isCatalogQuery(queryTables("SELECT @@version")))
+	assert.False(t, isCatalogQuery(queryTables("SELECT * FROM `users`")))
+}
+
+func TestCachedRowsScan(t *testing.T) {
+	now := time.Now()
+	rows := &cachedRows{result: &cachedResult{
+		Columns: []string{"id", "name", "ok", "price", "created_at", "data", "deleted_at"},
+		Rows:    [][]any{{int64(7), []byte("a"), int64(1), 1.5, now, []byte("raw"), nil}},
+	}}
+
+	var (
+		id        uint64
+		name      string
+		ok        bool
+		price     float64
+		createdAt time.Time
+		data      []byte
+		deletedAt sql.NullTime
+	)
+	assert.NotNil(t, rows.Scan(&id))
+	assert.True(t, rows.Next())
+	assert.Nil(t, rows.Scan(&id, &name, &ok, &price, &createdAt, &data, &deletedAt))
+	assert.Equal(t, uint64(7), id)
+	assert.Equal(t, "a", name)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5, price)
+	assert.True(t, now.Equal(createdAt))
+	assert.Equal(t, []byte("raw"), data)
+	assert.False(t, deletedAt.Valid)
+	assert.NotNil(t, rows.Scan(&id, &name, &ok, &price, &createdAt, &data, &name))
+	assert.False(t, rows.Next())
+}
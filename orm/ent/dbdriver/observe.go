@@ -0,0 +1,374 @@
+package dbdriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"github.com/zeromicro/go-zero/core/threading"
+	"github.com/zeromicro/go-zero/core/timex"
+	"github.com/zeromicro/go-zero/core/trace"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entenum"
+)
+
+const (
+	// DefaultSlowThreshold is the default duration of the slow statements logged by ObserveDriver.
+	DefaultSlowThreshold = 500 * time.Millisecond
+	// explainTimeout is the timeout of running EXPLAIN for the slow query.
+	explainTimeout = 5 * time.Second
+)
+
+var (
+	metricQueryDuration = metric.NewHistogramVec(&metric.HistogramVecOpts{
+		Namespace: "db",
+		Subsystem: "client",
+		Name:      "duration_ms",
+		Help:      "database client statement duration(ms) by table and operation.",
+		Labels:    []string{"table", "op"},
+		Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000},
+	})
+	metricQueryErrors = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "db",
+		Subsystem: "client",
+		Name:      "errors_total",
+		Help:      "database client statement errors by table and operation.",
+		Labels:    []string{"table", "op"},
+	})
+)
+
+// ObserveOption customizes the ObserveDriver.
+type ObserveOption func(d *ObserveDriver)
+
+// WithSlowThreshold sets the duration of the slow statements, which are logged with the redacted args,
+// the tenant and the request ID. The zero duration disables the slow log.
+func WithSlowThreshold(threshold time.Duration) ObserveOption {
+	return func(d *ObserveDriver) {
+		d.slowThreshold = threshold
+	}
+}
+
+// WithExplain runs EXPLAIN for the slow read-only queries in background and logs the plans.
+func WithExplain(explain bool) ObserveOption {
+	return func(d *ObserveDriver) {
+		d.explain = explain
+	}
+}
+
+// WithStatementLog logs all statements with the duration and the redacted args.
+func WithStatementLog(enable bool) ObserveOption {
+	return func(d *ObserveDriver) {
+		d.logAll = enable
+	}
+}
+
+// ObserveDriver records the duration of the statements by the prometheus histograms of table and operation,
+// traces them by the OpenTelemetry spans and logs the slow statements.
+//
+// The duration of Query does not include reading the rows. The spans record the statements without args,
+// and the string and bytes args are redacted in the logs.
+type ObserveDriver struct {
+	dialect.Driver
+	slowThreshold time.Duration
+	explain       bool
+	logAll        bool
+}
+
+// NewObserveDriver returns the driver observing the statements of drv, the default slow threshold
+// is DefaultSlowThreshold.
+func NewObserveDriver(drv dialect.Driver, opts ...ObserveOption) *ObserveDriver {
+	d := &ObserveDriver{Driver: drv, slowThreshold: DefaultSlowThreshold}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Exec executes the statement and observes it.
+func (d *ObserveDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.observe(ctx, query, args, func(ctx context.Context) error {
+		return d.Driver.Exec(ctx, query, args, v)
+	})
+}
+
+// Query executes the query and observes it.
+func (d *ObserveDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.observe(ctx, query, args, func(ctx context.Context) error {
+		return d.Driver.Query(ctx, query, args, v)
+	})
+}
+
+// QueryContext executes the query by the wrapped driver and observes it.
+func (d *ObserveDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support QueryContext")
+	}
+
+	var rows *sql.Rows
+	err := d.observe(ctx, query, args, func(ctx context.Context) (err error) {
+		rows, err = drv.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// ExecContext executes the statement by the wrapped driver and observes it.
+func (d *ObserveDriver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support ExecContext")
+	}
+
+	var result sql.Result
+	err := d.observe(ctx, query, args, func(ctx context.Context) (err error) {
+		result, err = drv.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// Tx starts a transaction which observes its statements.
+func (d *ObserveDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &observeTx{Tx: tx, driver: d}, nil
+}
+
+// BeginTx starts a transaction with options which observes its statements.
+func (d *ObserveDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support BeginTx")
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &observeTx{Tx: tx, driver: d}, nil
+}
+
+// observe runs the statement in a span, records its duration and logs it if it is slow.
+func (d *ObserveDriver) observe(ctx context.Context, query string, args any, fn func(context.Context) error) error {
+	op := queryOperation(query)
+	var table string
+	if tables := queryTables(query); len(tables) > 0 {
+		table = tables[0]
+	}
+
+	spanName := op
+	if table != "" {
+		spanName = op + " " + table
+	}
+	ctx, span := trace.TracerFromContext(ctx).Start(ctx, spanName, oteltrace.WithSpanKind(oteltrace.SpanKindClient))
+	span.SetAttributes(
+		attribute.String("db.system", d.Dialect()),
+		attribute.String("db.operation", op),
+		attribute.String("db.sql.table", table),
+		attribute.String("db.statement", query),
+	)
+
+	start := timex.Now()
+	err := fn(ctx)
+	duration := timex.Since(start)
+
+	if err == nil || errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Ok, "")
+	} else {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+		metricQueryErrors.Inc(table, op)
+	}
+	span.End()
+	metricQueryDuration.Observe(duration.Milliseconds(), table, op)
+
+	switch {
+	case d.slowThreshold > 0 && duration > d.slowThreshold:
+		logx.WithContext(ctx).WithDuration(duration).Sloww("slow sql", logFields(ctx, query, args, err)...)
+		if d.explain && isReadOnly(query) {
+			d.explainQuery(ctx, query, args)
+		}
+	case d.logAll:
+		logx.WithContext(ctx).WithDuration(duration).Infow("sql", logFields(ctx, query, args, err)...)
+	}
+	return err
+}
+
+// logFields returns the fields of the statement log with the redacted args.
+func logFields(ctx context.Context, query string, args any, err error) []logx.LogField {
+	fields := []logx.LogField{
+		logx.Field("sql", query),
+		logx.Field("args", redactArgs(args)),
+		logx.Field("tenantId", tenantID(ctx)),
+		logx.Field("requestId", trace.TraceIDFromContext(ctx)),
+	}
+	if err != nil {
+		fields = append(fields, logx.Field("detail", err.Error()))
+	}
+	return fields
+}
+
+// tenantID returns the tenant in context, the statements out of the requests have no tenant and
+// are logged as the default tenant without the error of tenantctx.
+func tenantID(ctx context.Context) uint64 {
+	if _, ok := ctx.Value(enum.TenantIdCtxKey).(string); !ok {
+		if md, ok := metadata.FromIncomingContext(ctx); !ok || len(md.Get(enum.TenantIdCtxKey)) == 0 {
+			return entenum.TenantDefaultId
+		}
+	}
+	return tenantctx.GetTenantIDFromCtx(ctx)
+}
+
+// explainQuery logs the plan of the query in background, the query is explained on the wrapped driver
+// instead of the transaction.
+func (d *ObserveDriver) explainQuery(ctx context.Context, query string, args any) {
+	prefix := "EXPLAIN "
+	if d.Dialect() == dialect.SQLite {
+		prefix = "EXPLAIN QUERY PLAN "
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	threading.GoSafe(func() {
+		ctx, cancel := context.WithTimeout(ctx, explainTimeout)
+		defer cancel()
+
+		rows := &entsql.Rows{}
+		if err := d.Driver.Query(ctx, prefix+query, args, rows); err != nil {
+			logx.WithContext(ctx).Errorw("failed to explain slow sql", logx.Field("sql", query),
+				logx.Field("detail", err.Error()))
+			return
+		}
+		result, err := readRows(rows)
+		if err != nil {
+			logx.WithContext(ctx).Errorw("failed to explain slow sql", logx.Field("sql", query),
+				logx.Field("detail", err.Error()))
+			return
+		}
+
+		logx.WithContext(ctx).Sloww("slow sql plan", logx.Field("sql", query),
+			logx.Field("plan", formatPlan(result)))
+	})
+}
+
+// observeTx observes the statements in the transaction.
+type observeTx struct {
+	dialect.Tx
+	driver *ObserveDriver
+}
+
+func (tx *observeTx) Exec(ctx context.Context, query string, args, v any) error {
+	return tx.driver.observe(ctx, query, args, func(ctx context.Context) error {
+		return tx.Tx.Exec(ctx, query, args, v)
+	})
+}
+
+func (tx *observeTx) Query(ctx context.Context, query string, args, v any) error {
+	return tx.driver.observe(ctx, query, args, func(ctx context.Context) error {
+		return tx.Tx.Query(ctx, query, args, v)
+	})
+}
+
+func (tx *observeTx) ExecContext(ctx context.Context, query string, args ...any) (res sql.Result, err error) {
+	err = tx.driver.observe(ctx, query, args, func(ctx context.Context) error {
+		res, err = txExecContext(ctx, tx.Tx, query, args...)
+		return err
+	})
+	return res, err
+}
+
+func (tx *observeTx) QueryContext(ctx context.Context, query string, args ...any) (rows *sql.Rows, err error) {
+	err = tx.driver.observe(ctx, query, args, func(ctx context.Context) error {
+		rows, err = txQueryContext(ctx, tx.Tx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// queryOperation returns the upper case first keyword of the statement, such as SELECT and INSERT.
+func queryOperation(query string) string {
+	q := strings.TrimLeft(query, " \t\r\n(")
+	if i := strings.IndexAny(q, " \t\r\n("); i > 0 {
+		q = q[:i]
+	}
+	if q == "" {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(q)
+}
+
+// redactArgs returns the args for logging, the strings and bytes are replaced by their lengths.
+func redactArgs(args any) []string {
+	values, ok := args.([]any)
+	if !ok {
+		return nil
+	}
+
+	redacted := make([]string, 0, len(values))
+	for _, v := range values {
+		redacted = append(redacted, redactValue(v))
+	}
+	return redacted
+}
+
+func redactValue(v any) string {
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return fmt.Sprintf("<%T>", v)
+		}
+		v = value
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	case string:
+		return fmt.Sprintf("<string:%d>", len(val))
+	case []byte:
+		return fmt.Sprintf("<bytes:%d>", len(val))
+	default:
+		return fmt.Sprintf("<%T>", v)
+	}
+}
+
+// formatPlan returns the rows of the EXPLAIN result in lines.
+func formatPlan(result *cachedResult) string {
+	lines := make([]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		values := make([]string, 0, len(row))
+		for _, v := range row {
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			values = append(values, fmt.Sprint(v))
+		}
+		lines = append(lines, strings.Join(values, " | "))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,111 @@
+package dbdriver
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace/tracetest"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func collectLogs(t *testing.T) *syncBuffer {
+	buf := &syncBuffer{}
+	prev := logx.Reset()
+	logx.SetWriter(logx.NewWriter(buf))
+	t.Cleanup(func() {
+		logx.SetWriter(prev)
+	})
+	return buf
+}
+
+func TestQueryOperation(t *testing.T) {
+	assert.Equal(t, "SELECT", queryOperation("select * from users"))
+	assert.Equal(t, "INSERT", queryOperation("\n INSERT INTO users (name) VALUES (?)"))
+	assert.Equal(t, "SELECT", queryOperation("(SELECT 1) UNION (SELECT 2)"))
+	assert.Equal(t, "UNKNOWN", queryOperation(" "))
+}
+
+func TestRedactArgs(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	id := uuid.Must(uuid.FromString("2f5a6b6e-7c1d-4e0f-9a3b-2c4d5e6f7a8b"))
+	assert.Equal(t, []string{"NULL", "1", "true", "1.5", "2024-01-02T03:04:05Z", "<string:6>", "<bytes:2>", "<string:36>",
+		"<[]int>"},
+		redactArgs([]any{nil, 1, true, 1.5, now, "secret", []byte("pw"), id, []int{1}}))
+	assert.Nil(t, redactArgs(nil))
+}
+
+func TestObserveDriver(t *testing.T) {
+	ctx := tenantctx.WithTenantIdCtx(context.Background(), "3")
+	logs := collectLogs(t)
+	spans := tracetest.NewInMemoryExporter(t)
+
+	drv := NewObserveDriver(openTestDB(t, "observe", "alice"), WithSlowThreshold(time.Nanosecond), WithExplain(true))
+	defer drv.Close()
+
+	assert.Equal(t, "alice", queryName(t, ctx, drv, "SELECT name FROM items WHERE name = ?", "alice"))
+	assert.Contains(t, logs.String(), "slow sql")
+	assert.Contains(t, logs.String(), `"args":["<string:5>"]`)
+	assert.Contains(t, logs.String(), `"tenantId":3`)
+	assert.NotContains(t, logs.String(), `"alice"`)
+
+	// the plan is logged in background
+	assert.Eventually(t, func() bool {
+		return strings.Contains(logs.String(), "slow sql plan")
+	}, time.Second, 10*time.Millisecond)
+
+	tx, err := drv.Tx(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Exec(ctx, "INSERT INTO items (name) VALUES (?)", []any{"bob"}, nil))
+	assert.Nil(t, tx.Commit())
+	assert.NotNil(t, drv.Exec(ctx, "INSERT INTO missing (name) VALUES (?)", []any{"x"}, nil))
+
+	var names []string
+	for _, v := range spans.GetSpans() {
+		names = append(names, v.Name)
+	}
+	assert.Contains(t, names, "SELECT items")
+	assert.Contains(t, names, "INSERT items")
+	assert.Contains(t, names, "INSERT missing")
+	assert.Equal(t, map[string]*sql.DB{"observe": drv.Driver.(*entsql.Driver).DB()}, Pools("observe", drv))
+}
+
+func TestObserveDriverStatementLog(t *testing.T) {
+	ctx := context.Background()
+	logs := collectLogs(t)
+
+	drv := NewObserveDriver(openTestDB(t, "observe_log", "alice"), WithSlowThreshold(0))
+	defer drv.Close()
+	assert.Equal(t, "alice", queryName(t, ctx, drv, "SELECT name FROM items LIMIT 1"))
+	assert.NotContains(t, logs.String(), "SELECT name FROM items")
+
+	drv = NewObserveDriver(drv.Driver, WithSlowThreshold(time.Hour), WithStatementLog(true))
+	assert.Equal(t, "alice", queryName(t, ctx, drv, "SELECT name FROM items LIMIT 1"))
+	assert.Contains(t, logs.String(), "SELECT name FROM items")
+	assert.NotContains(t, logs.String(), "slow sql")
+}
@@ -0,0 +1,205 @@
+package dbdriver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// DefaultReplicaCheckInterval is the default interval of the replica health check.
+const DefaultReplicaCheckInterval = 10 * time.Second
+
+// lockingClauses are the row locks of MySQL and Postgres which must be sent to the primary.
+var lockingClauses = []string{" FOR UPDATE", " FOR NO KEY UPDATE", " FOR SHARE", " FOR KEY SHARE",
+	" LOCK IN SHARE MODE"}
+
+type primaryKey struct{}
+
+// WithPrimary returns context which sends the queries to the primary, such as reading the data just written.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// IsPrimary returns true if the context forces the queries to the primary.
+func IsPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// ReplicaDriver sends the read-only queries to the healthy replicas in round-robin order, and the writes
+// and transactions to the primary. The queries are sent to the primary if all replicas are unhealthy.
+// The migration inspects the tables by queries, so run it with WithPrimary context:
+//
+//	client.Schema.Create(dbdriver.WithPrimary(ctx))
+type ReplicaDriver struct {
+	dialect.Driver
+	replicas []*replica
+	next     atomic.Uint64
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+type replica struct {
+	dialect.Driver
+	healthy atomic.Bool
+}
+
+// NewReplicaDriver returns the driver of the primary and replicas, and checks the health of the replicas
+// before it returns and every interval, DefaultReplicaCheckInterval is used if interval <= 0.
+// The replicas unreachable on startup are skipped until they recover.
+func NewReplicaDriver(primary dialect.Driver, replicas []dialect.Driver, interval time.Duration) *ReplicaDriver {
+	if interval <= 0 {
+		interval = DefaultReplicaCheckInterval
+	}
+
+	d := &ReplicaDriver{Driver: primary, stop: make(chan struct{})}
+	for _, v := range replicas {
+		r := &replica{Driver: v}
+		r.healthy.Store(true)
+		d.replicas = append(d.replicas, r)
+	}
+
+	if len(d.replicas) > 0 {
+		d.checkReplicas(interval)
+		threading.GoSafe(func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-d.stop:
+					return
+				case <-ticker.C:
+					d.checkReplicas(interval)
+				}
+			}
+		})
+	}
+	return d
+}
+
+// Query sends the read-only query to a replica unless the context forces the primary.
+func (d *ReplicaDriver) Query(ctx context.Context, query string, args, v any) error {
+	return d.reader(ctx, query).Query(ctx, query, args, v)
+}
+
+// QueryContext sends the read-only query to a replica unless the context forces the primary.
+func (d *ReplicaDriver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.reader(ctx, query).(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support QueryContext")
+	}
+	return drv.QueryContext(ctx, query, args...)
+}
+
+// ExecContext executes the query on the primary.
+func (d *ReplicaDriver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support ExecContext")
+	}
+	return drv.ExecContext(ctx, query, args...)
+}
+
+// BeginTx starts a transaction on the primary with options.
+func (d *ReplicaDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: driver does not support BeginTx")
+	}
+	return drv.BeginTx(ctx, opts)
+}
+
+// Close stops the health check and closes the primary and replicas.
+func (d *ReplicaDriver) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+	})
+
+	var errs []error
+	for _, v := range d.replicas {
+		errs = append(errs, v.Close())
+	}
+	errs = append(errs, d.Driver.Close())
+	return errors.Join(errs...)
+}
+
+// reader returns the driver of the query, the writes such as INSERT ... RETURNING and the
+// locking reads are sent to the primary.
+func (d *ReplicaDriver) reader(ctx context.Context, query string) dialect.Driver {
+	if len(d.replicas) == 0 || IsPrimary(ctx) || !isReadOnly(query) {
+		return d.Driver
+	}
+
+	n := uint64(len(d.replicas))
+	start := d.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := d.replicas[(start+i)%n]; r.healthy.Load() {
+			return r.Driver
+		}
+	}
+	return d.Driver
+}
+
+func (d *ReplicaDriver) checkReplicas(timeout time.Duration) {
+	for i, v := range d.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := ping(ctx, v.Driver)
+		cancel()
+
+		healthy := err == nil
+		if v.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			logx.Infow("database replica is recovered", logx.Field("replica", i))
+		} else {
+			logx.Errorw("database replica is unhealthy", logx.Field("replica", i), logx.Field("detail", err.Error()))
+		}
+	}
+}
+
+// ping checks the connection of the driver by the database/sql pool if possible.
+func ping(ctx context.Context, drv dialect.Driver) error {
+	if db, ok := drv.(interface{ DB() *sql.DB }); ok {
+		return db.DB().PingContext(ctx)
+	}
+
+	rows := &entsql.Rows{}
+	if err := drv.Query(ctx, "SELECT 1", []any{}, rows); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// isReadOnly returns true if the query is a SELECT without the row locks.
+func isReadOnly(query string) bool {
+	q := strings.TrimSpace(query)
+	if len(q) < 6 || !strings.EqualFold(q[:6], "SELECT") {
+		return false
+	}
+
+	q = strings.ToUpper(q)
+	for _, v := range lockingClauses {
+		if strings.Contains(q, v) {
+			return false
+		}
+	}
+	return true
+}
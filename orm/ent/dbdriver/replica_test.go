@@ -0,0 +1,83 @@
+package dbdriver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func openTestDB(t *testing.T, name, value string) *entsql.Driver {
+	drv, err := entsql.Open(dialect.SQLite, "file:"+name+"?mode=memory&cache=shared&_fk=1")
+	assert.Nil(t, err)
+
+	ctx := context.Background()
+	assert.Nil(t, drv.Exec(ctx, "CREATE TABLE IF NOT EXISTS items (name text)", []any{}, nil))
+	assert.Nil(t, drv.Exec(ctx, "INSERT INTO items (name) VALUES (?)", []any{value}, nil))
+	return drv
+}
+
+func queryName(t *testing.T, ctx context.Context, drv dialect.ExecQuerier, query string, args ...any) string {
+	rows := &entsql.Rows{}
+	assert.Nil(t, drv.Query(ctx, query, append([]any{}, args...), rows))
+	defer rows.Close()
+
+	var name string
+	assert.Nil(t, entsql.ScanOne(rows, &name))
+	return name
+}
+
+func TestReplicaDriver(t *testing.T) {
+	ctx := context.Background()
+	primary := openTestDB(t, "replica_primary", "primary")
+	r1 := openTestDB(t, "replica_1", "r1")
+	r2 := openTestDB(t, "replica_2", "r2")
+
+	drv := NewReplicaDriver(primary, []dialect.Driver{r1, r2}, time.Hour)
+	defer drv.Close()
+
+	got := map[string]int{}
+	for i := 0; i < 4; i++ {
+		got[queryName(t, ctx, drv, "SELECT name FROM items LIMIT 1")]++
+	}
+	assert.Equal(t, map[string]int{"r1": 2, "r2": 2}, got)
+
+	assert.Equal(t, "primary", queryName(t, WithPrimary(ctx), drv, "SELECT name FROM items LIMIT 1"))
+	assert.Equal(t, "x", queryName(t, ctx, drv, "INSERT INTO items (name) VALUES ('x') RETURNING name"))
+	assert.Equal(t, "x", queryName(t, ctx, primary, "SELECT name FROM items WHERE name = 'x'"))
+
+	tx, err := drv.Tx(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "primary", queryName(t, ctx, tx, "SELECT name FROM items LIMIT 1"))
+	assert.Nil(t, tx.Rollback())
+
+	// the unhealthy replicas are skipped
+	drv.replicas[0].healthy.Store(false)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, "r2", queryName(t, ctx, drv, "SELECT name FROM items LIMIT 1"))
+	}
+	drv.replicas[1].healthy.Store(false)
+	assert.Equal(t, "primary", queryName(t, ctx, drv, "SELECT name FROM items LIMIT 1"))
+
+	drv.checkReplicas(time.Second)
+	assert.True(t, drv.replicas[0].healthy.Load())
+	assert.True(t, drv.replicas[1].healthy.Load())
+
+	assert.Nil(t, r2.Close())
+	drv.checkReplicas(time.Second)
+	assert.False(t, drv.replicas[1].healthy.Load())
+}
+
+func TestIsReadOnly(t *testing.T) {
+	assert.True(t, isReadOnly("  select * from users"))
+	assert.True(t, isReadOnly("SELECT `id` FROM `users` WHERE `name` = ?"))
+	assert.False(t, isReadOnly("SELECT * FROM users FOR UPDATE"))
+	assert.False(t, isReadOnly("SELECT * FROM users for no key update"))
+	assert.False(t, isReadOnly("SELECT * FROM users LOCK IN SHARE MODE"))
+	assert.False(t, isReadOnly("INSERT INTO users (name) VALUES ($1) RETURNING id"))
+	assert.False(t, isReadOnly("UPDATE users SET name = ?"))
+}
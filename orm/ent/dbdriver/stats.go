@@ -0,0 +1,198 @@
+package dbdriver
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// DefaultStatsInterval is the default interval of reporting the pool stats.
+const DefaultStatsInterval = 15 * time.Second
+
+// driverWrapper is implemented by the drivers wrapping other drivers, it returns the wrapped drivers
+// by name suffix, and the empty suffix is the main driver.
+type driverWrapper interface {
+	wrappedDrivers() map[string]dialect.Driver
+}
+
+func (d *ReplicaDriver) wrappedDrivers() map[string]dialect.Driver {
+	drivers := map[string]dialect.Driver{"": d.Driver}
+	for i, v := range d.replicas {
+		drivers["replica-"+strconv.Itoa(i)] = v.Driver
+	}
+	return drivers
+}
+
+func (d *TenantDriver) wrappedDrivers() map[string]dialect.Driver {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	drivers := map[string]dialect.Driver{"": d.Driver}
+	for e := d.lru.Front(); e != nil; e = e.Next() {
+		conn := e.Value.(*tenantConn)
+		drivers["tenant-"+tenantDBName(conn.db)] = conn.drv
+	}
+	return drivers
+}
+
+func (d *CacheDriver) wrappedDrivers() map[string]dialect.Driver {
+	return map[string]dialect.Driver{"": d.Driver}
+}
+
+func (d *ObserveDriver) wrappedDrivers() map[string]dialect.Driver {
+	return map[string]dialect.Driver{"": d.Driver}
+}
+
+// tenantDBName returns the schema or the hash of the DSN, which may contain the password.
+func tenantDBName(db TenantDB) string {
+	if db.Schema != "" {
+		return db.Schema
+	}
+
+	sum := sha256.Sum256([]byte(db.DSN))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Pools returns the database/sql pools of the driver and the wrapped drivers by name, such as
+// name, name-replica-0 and name-tenant-schema.
+func Pools(name string, drv dialect.Driver) map[string]*sql.DB {
+	pools := map[string]*sql.DB{}
+	collectPools(name, drv, pools)
+	return pools
+}
+
+func collectPools(name string, drv dialect.Driver, pools map[string]*sql.DB) {
+	switch d := drv.(type) {
+	case interface{ DB() *sql.DB }:
+		pools[name] = d.DB()
+	case driverWrapper:
+		for suffix, v := range d.wrappedDrivers() {
+			if suffix == "" {
+				collectPools(name, v, pools)
+			} else {
+				collectPools(name+"-"+suffix, v, pools)
+			}
+		}
+	}
+}
+
+// Stats returns the pool stats of the driver and the wrapped drivers by name.
+func Stats(name string, drv dialect.Driver) map[string]sql.DBStats {
+	pools := Pools(name, drv)
+	stats := make(map[string]sql.DBStats, len(pools))
+	for k, v := range pools {
+		stats[k] = v.Stats()
+	}
+	return stats
+}
+
+// Ping checks the connection of the primary pool of the driver. The other pools such as the replicas
+// and the tenant databases are logged as degraded if they fail, which does not fail the health check,
+// since the replicas fall back to the primary and a tenant database only affects its tenant.
+func Ping(ctx context.Context, drv dialect.Driver) error {
+	var err error
+	for name, v := range Pools("db", drv) {
+		pingErr := v.PingContext(ctx)
+		switch {
+		case pingErr == nil:
+		case name == "db":
+			err = fmt.Errorf("%s: %w", name, pingErr)
+		default:
+			logx.WithContext(ctx).Errorw("database pool is degraded", logx.Field("db", name),
+				logx.Field("detail", pingErr.Error()))
+		}
+	}
+	return err
+}
+
+var (
+	poolMetricsOnce sync.Once
+	poolConnections metric.GaugeVec
+	poolWaitCount   metric.GaugeVec
+	poolWaitSeconds metric.GaugeVec
+	poolClosed      metric.GaugeVec
+)
+
+func initPoolMetrics() {
+	poolMetricsOnce.Do(func() {
+		poolConnections = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: "db",
+			Subsystem: "pool",
+			Name:      "connections",
+			Help:      "database pool connections by state.",
+			Labels:    []string{"db", "state"},
+		})
+		poolWaitCount = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: "db",
+			Subsystem: "pool",
+			Name:      "wait_count",
+			Help:      "database pool total count of waiting for a connection.",
+			Labels:    []string{"db"},
+		})
+		poolWaitSeconds = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: "db",
+			Subsystem: "pool",
+			Name:      "wait_seconds",
+			Help:      "database pool total seconds of waiting for a connection.",
+			Labels:    []string{"db"},
+		})
+		poolClosed = metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: "db",
+			Subsystem: "pool",
+			Name:      "closed_connections",
+			Help:      "database pool total closed connections by reason.",
+			Labels:    []string{"db", "reason"},
+		})
+	})
+}
+
+// ReportStats sets the pool stats of the driver to the prometheus metrics every interval, it is enabled
+// by the prometheus config of go-zero. Call the returned function to stop it.
+func ReportStats(name string, drv dialect.Driver, interval time.Duration) func() {
+	if interval <= 0 {
+		interval = DefaultStatsInterval
+	}
+	initPoolMetrics()
+
+	done := make(chan struct{})
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				for db, v := range Stats(name, drv) {
+					poolConnections.Set(float64(v.MaxOpenConnections), db, "max_open")
+					poolConnections.Set(float64(v.OpenConnections), db, "open")
+					poolConnections.Set(float64(v.InUse), db, "in_use")
+					poolConnections.Set(float64(v.Idle), db, "idle")
+					poolWaitCount.Set(float64(v.WaitCount), db)
+					poolWaitSeconds.Set(v.WaitDuration.Seconds(), db)
+					poolClosed.Set(float64(v.MaxIdleClosed), db, "max_idle")
+					poolClosed.Set(float64(v.MaxIdleTimeClosed), db, "max_idle_time")
+					poolClosed.Set(float64(v.MaxLifetimeClosed), db, "max_lifetime")
+				}
+			}
+		}
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package dbdriver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPools(t *testing.T) {
+	primary := openTestDB(t, "stats_primary", "primary")
+	replica := openTestDB(t, "stats_replica", "replica")
+	tenant := openTestDB(t, "stats_tenant", "tenant")
+
+	replicaDrv := NewReplicaDriver(primary, []dialect.Driver{replica}, time.Hour)
+	drv := NewCacheDriver(NewTenantDriver(replicaDrv, StaticTenantRegistry{}, nil), NewMemoryCacheStore(0), time.Minute)
+	drv.Driver.(*TenantDriver).lru.PushFront(&tenantConn{db: TenantDB{Schema: "t2"}, drv: tenant})
+
+	pools := Pools("admin", drv)
+	assert.Len(t, pools, 3)
+	assert.Equal(t, primary.DB(), pools["admin"])
+	assert.Equal(t, replica.DB(), pools["admin-replica-0"])
+	assert.Equal(t, tenant.DB(), pools["admin-tenant-t2"])
+	assert.Len(t, Stats("admin", drv), 3)
+
+	assert.Nil(t, Ping(context.Background(), drv))
+	// the replicas and tenants are degraded without failing the ping
+	logs := collectLogs(t)
+	assert.Nil(t, replica.Close())
+	assert.Nil(t, tenant.Close())
+	assert.Nil(t, Ping(context.Background(), drv))
+	assert.Contains(t, logs.String(), "db-replica-0")
+	assert.Contains(t, logs.String(), "db-tenant-t2")
+	assert.Nil(t, primary.Close())
+	assert.ErrorContains(t, Ping(context.Background(), drv), "db: ")
+
+	stop := ReportStats("admin", drv, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	stop()
+	stop()
+}
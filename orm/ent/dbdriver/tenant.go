@@ -0,0 +1,444 @@
+package dbdriver
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+const (
+	// DefaultMaxTenantDBs is the default count of the tenant databases kept open.
+	DefaultMaxTenantDBs = 32
+	// DefaultTenantIdleTime is the default idle time before the tenant database is closed.
+	DefaultTenantIdleTime = 10 * time.Minute
+	// DefaultTenantResolveTTL is the default cache time of the tenant databases loaded from the registry.
+	DefaultTenantResolveTTL = time.Minute
+)
+
+// TenantDB is the isolated database of a tenant.
+type TenantDB struct {
+	// DSN is the dedicated database of the tenant, the default database is used if it is empty.
+	DSN string `json:"dsn,omitempty"`
+	// Schema is the Postgres schema of the tenant, which is set as the search_path of the connections.
+	Schema string `json:"schema,omitempty"`
+}
+
+// TenantRegistry loads the isolated databases of the tenants.
+type TenantRegistry interface {
+	// GetTenantDB returns the database of the tenant, nil means the tenant uses the default database.
+	GetTenantDB(ctx context.Context, tenantId uint64) (*TenantDB, error)
+}
+
+// TenantRegistryFunc is the function implementing TenantRegistry.
+type TenantRegistryFunc func(ctx context.Context, tenantId uint64) (*TenantDB, error)
+
+func (f TenantRegistryFunc) GetTenantDB(ctx context.Context, tenantId uint64) (*TenantDB, error) {
+	return f(ctx, tenantId)
+}
+
+// StaticTenantRegistry is the registry of the fixed tenant databases, such as loaded from the config.
+type StaticTenantRegistry map[uint64]TenantDB
+
+func (r StaticTenantRegistry) GetTenantDB(_ context.Context, tenantId uint64) (*TenantDB, error) {
+	if db, ok := r[tenantId]; ok {
+		return &db, nil
+	}
+	return nil, nil
+}
+
+// TenantOpener opens the driver of the tenant database.
+type TenantOpener func(db TenantDB) (dialect.Driver, error)
+
+// TenantOption is the option of TenantDriver.
+type TenantOption func(d *TenantDriver)
+
+// WithMaxTenantDBs sets the count of the tenant databases kept open, the least recently used idle
+// database is closed when it is exceeded.
+func WithMaxTenantDBs(n int) TenantOption {
+	return func(d *TenantDriver) {
+		if n > 0 {
+			d.maxDBs = n
+		}
+	}
+}
+
+// WithTenantIdleTime sets the idle time before the tenant database is closed.
+func WithTenantIdleTime(idle time.Duration) TenantOption {
+	return func(d *TenantDriver) {
+		if idle > 0 {
+			d.idleTime = idle
+		}
+	}
+}
+
+// WithTenantResolveTTL sets the cache time of the tenant databases loaded from the registry.
+func WithTenantResolveTTL(ttl time.Duration) TenantOption {
+	return func(d *TenantDriver) {
+		if ttl > 0 {
+			d.resolveTTL = ttl
+		}
+	}
+}
+
+// TenantDriver sends the statements and transactions to the database of the tenant in context, which is
+// loaded from the registry. The tenants without isolated database and WithIgnoreTenant context use the
+// default driver, while the tenant admin is still routed by the tenant in context. The migration is also
+// routed, so run it for each isolated tenant:
+//
+//	client.Schema.Create(tenantctx.WithTenantId(ctx, tenantId))
+type TenantDriver struct {
+	dialect.Driver
+	registry TenantRegistry
+	open     TenantOpener
+
+	maxDBs     int
+	idleTime   time.Duration
+	resolveTTL time.Duration
+
+	mu       sync.Mutex
+	dbs      map[TenantDB]*list.Element
+	lru      *list.List
+	resolved map[uint64]resolvedTenant
+
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+type tenantConn struct {
+	db       TenantDB
+	drv      dialect.Driver
+	inUse    int
+	lastUsed time.Time
+}
+
+type resolvedTenant struct {
+	db       *TenantDB
+	expireAt time.Time
+}
+
+// NewTenantDriver returns the driver routing by tenant, def is the default driver and open opens the
+// isolated databases loaded from the registry.
+func NewTenantDriver(def dialect.Driver, registry TenantRegistry, open TenantOpener, opts ...TenantOption) *TenantDriver {
+	d := &TenantDriver{
+		Driver:     def,
+		registry:   registry,
+		open:       open,
+		maxDBs:     DefaultMaxTenantDBs,
+		idleTime:   DefaultTenantIdleTime,
+		resolveTTL: DefaultTenantResolveTTL,
+		dbs:        map[TenantDB]*list.Element{},
+		lru:        list.New(),
+		resolved:   map[uint64]resolvedTenant{},
+		stop:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	interval := max(d.idleTime/2, time.Second)
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				d.evictIdle()
+			}
+		}
+	})
+	return d
+}
+
+// Exec executes the query on the database of the tenant.
+func (d *TenantDriver) Exec(ctx context.Context, query string, args, v any) error {
+	drv, release, err := d.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return drv.Exec(ctx, query, args, v)
+}
+
+// Query executes the query on the database of the tenant.
+func (d *TenantDriver) Query(ctx context.Context, query string, args, v any) error {
+	drv, release, err := d.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return drv.Query(ctx, query, args, v)
+}
+
+// Tx starts a transaction on the database of the tenant, the database is kept open until the transaction
+// is committed or rolled back.
+func (d *TenantDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	drv, release, err := d.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := drv.Tx(ctx)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &tenantTx{Tx: tx, release: release}, nil
+}
+
+// BeginTx starts a transaction on the database of the tenant with options, the database is kept open
+// until the transaction is committed or rolled back.
+func (d *TenantDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, release, err := d.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	beginner, ok := drv.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		release()
+		return nil, errors.New("dbdriver: driver does not support BeginTx")
+	}
+
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return &tenantTx{Tx: tx, release: release}, nil
+}
+
+// Invalidate removes the cached databases of the tenants loaded from the registry, the open databases
+// are closed when they are idle.
+func (d *TenantDriver) Invalidate(tenantIds ...uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, v := range tenantIds {
+		delete(d.resolved, v)
+	}
+}
+
+// Close stops the eviction and closes all databases.
+func (d *TenantDriver) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.stop)
+	})
+
+	d.mu.Lock()
+	conns := make([]*tenantConn, 0, d.lru.Len())
+	for e := d.lru.Front(); e != nil; e = e.Next() {
+		conns = append(conns, e.Value.(*tenantConn))
+	}
+	d.dbs = map[TenantDB]*list.Element{}
+	d.lru.Init()
+	d.mu.Unlock()
+
+	var errs []error
+	for _, v := range conns {
+		errs = append(errs, v.drv.Close())
+	}
+	errs = append(errs, d.Driver.Close())
+	return errors.Join(errs...)
+}
+
+// acquire returns the driver of the tenant in context, call release after the statement is executed.
+func (d *TenantDriver) acquire(ctx context.Context) (dialect.Driver, func(), error) {
+	if tenantctx.GetIgnoreTenantFromCtx(ctx) {
+		return d.Driver, func() {}, nil
+	}
+
+	db, err := d.resolve(ctx, tenantctx.GetTenantIDFromCtx(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	if db == nil || *db == (TenantDB{}) {
+		return d.Driver, func() {}, nil
+	}
+
+	d.mu.Lock()
+	if e, ok := d.dbs[*db]; ok {
+		d.lru.MoveToFront(e)
+		defer d.mu.Unlock()
+		return d.use(e.Value.(*tenantConn))
+	}
+	d.mu.Unlock()
+
+	// the database is opened without the lock, which may take seconds to connect
+	drv, err := d.open(*db)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d.mu.Lock()
+	if e, ok := d.dbs[*db]; ok {
+		// opened by others concurrently
+		d.lru.MoveToFront(e)
+		used, release, err := d.use(e.Value.(*tenantConn))
+		d.mu.Unlock()
+		_ = drv.Close()
+		return used, release, err
+	}
+
+	conn := &tenantConn{db: *db, drv: drv}
+	d.dbs[*db] = d.lru.PushFront(conn)
+	// the new database is in use before the eviction, so it is not evicted itself
+	used, release, err := d.use(conn)
+	evicted := d.evictOverflow()
+	d.mu.Unlock()
+
+	closeConns(evicted)
+	return used, release, err
+}
+
+// use marks the connection in use, it is called with the lock. The databases kept open over the limit
+// by the connections in use are closed on release.
+func (d *TenantDriver) use(conn *tenantConn) (dialect.Driver, func(), error) {
+	conn.inUse++
+	return conn.drv, func() {
+		d.mu.Lock()
+		conn.inUse--
+		conn.lastUsed = time.Now()
+		evicted := d.evictOverflow()
+		d.mu.Unlock()
+
+		closeConns(evicted)
+	}, nil
+}
+
+// resolve returns the database of the tenant from the cache or registry.
+func (d *TenantDriver) resolve(ctx context.Context, tenantId uint64) (*TenantDB, error) {
+	d.mu.Lock()
+	r, ok := d.resolved[tenantId]
+	d.mu.Unlock()
+	if ok && time.Now().Before(r.expireAt) {
+		return r.db, nil
+	}
+
+	db, err := d.registry.GetTenantDB(ctx, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	d.resolved[tenantId] = resolvedTenant{db: db, expireAt: time.Now().Add(d.resolveTTL)}
+	d.mu.Unlock()
+	return db, nil
+}
+
+// evictOverflow removes the least recently used idle databases over the limit and returns them to be closed
+// without the lock, it is called with the lock.
+func (d *TenantDriver) evictOverflow() []*tenantConn {
+	var evicted []*tenantConn
+	for e := d.lru.Back(); e != nil && d.lru.Len() > d.maxDBs; {
+		prev := e.Prev()
+		if conn := e.Value.(*tenantConn); conn.inUse == common.Zero {
+			evicted = append(evicted, d.remove(e))
+		}
+		e = prev
+	}
+	return evicted
+}
+
+// evictIdle closes the databases which are idle longer than the idle time, and removes the expired
+// databases loaded from the registry.
+func (d *TenantDriver) evictIdle() {
+	d.mu.Lock()
+	var evicted []*tenantConn
+	for e := d.lru.Back(); e != nil; {
+		prev := e.Prev()
+		if conn := e.Value.(*tenantConn); conn.inUse == common.Zero && time.Since(conn.lastUsed) > d.idleTime {
+			evicted = append(evicted, d.remove(e))
+		}
+		e = prev
+	}
+
+	now := time.Now()
+	for k, v := range d.resolved {
+		if !now.Before(v.expireAt) {
+			delete(d.resolved, k)
+		}
+	}
+	d.mu.Unlock()
+
+	closeConns(evicted)
+}
+
+// remove removes the database from the cache, it is called with the lock.
+func (d *TenantDriver) remove(e *list.Element) *tenantConn {
+	conn := d.lru.Remove(e).(*tenantConn)
+	delete(d.dbs, conn.db)
+	return conn
+}
+
+// closeConns closes the removed databases, the open transactions and rows are finished before the
+// connections are closed.
+func closeConns(conns []*tenantConn) {
+	for _, v := range conns {
+		if err := v.drv.Close(); err != nil {
+			logx.Errorw("failed to close tenant database", logx.Field("schema", v.db.Schema),
+				logx.Field("detail", err.Error()))
+		}
+	}
+}
+
+// tenantTx releases the database of the tenant when the transaction is committed or rolled back.
+type tenantTx struct {
+	dialect.Tx
+	release     func()
+	releaseOnce sync.Once
+}
+
+func (tx *tenantTx) Commit() error {
+	defer tx.releaseOnce.Do(tx.release)
+	return tx.Tx.Commit()
+}
+
+func (tx *tenantTx) Rollback() error {
+	defer tx.releaseOnce.Do(tx.release)
+	return tx.Tx.Rollback()
+}
+
+func (tx *tenantTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return txExecContext(ctx, tx.Tx, query, args...)
+}
+
+func (tx *tenantTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return txQueryContext(ctx, tx.Tx, query, args...)
+}
+
+// txExecContext calls the ExecContext of the transaction, the transaction wrappers expose it for the
+// ent clients generated with the sql/execquery feature.
+func txExecContext(ctx context.Context, tx dialect.Tx, query string, args ...any) (sql.Result, error) {
+	ex, ok := tx.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: Tx.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// txQueryContext calls the QueryContext of the transaction like txExecContext.
+func txQueryContext(ctx context.Context, tx dialect.Tx, query string, args ...any) (*sql.Rows, error) {
+	q, ok := tx.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, errors.New("dbdriver: Tx.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
@@ -0,0 +1,141 @@
+package dbdriver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+func TestTenantDriver(t *testing.T) {
+	ctx := context.Background()
+	def := openTestDB(t, "tenant_default", "default")
+	registry := StaticTenantRegistry{
+		2: {DSN: "tenant_2"},
+		3: {DSN: "tenant_3"},
+		4: {DSN: "tenant_2"},
+	}
+
+	opened := map[string]int{}
+	open := func(db TenantDB) (dialect.Driver, error) {
+		if db.DSN == "tenant_fail" {
+			return nil, errors.New("failed to connect")
+		}
+		opened[db.DSN]++
+		return openTestDB(t, db.DSN, db.DSN), nil
+	}
+
+	drv := NewTenantDriver(def, registry, open, WithMaxTenantDBs(1), WithTenantIdleTime(time.Hour))
+	defer drv.Close()
+
+	query := "SELECT name FROM items LIMIT 1"
+	assert.Equal(t, "default", queryName(t, tenantctx.WithTenantId(ctx, 1), drv, query))
+	assert.Equal(t, "tenant_2", queryName(t, tenantctx.WithTenantId(ctx, 2), drv, query))
+	assert.Equal(t, "tenant_2", queryName(t, tenantctx.WithTenantId(ctx, 4), drv, query))
+	assert.Equal(t, 1, opened["tenant_2"])
+	assert.Equal(t, "default", queryName(t, tenantctx.WithIgnoreTenant(tenantctx.WithTenantId(ctx, 2)), drv, query))
+	// the tenant admin acting for the tenant uses its database
+	assert.Equal(t, "tenant_2", queryName(t, tenantctx.AdminCtx(tenantctx.WithTenantId(ctx, 2)), drv, query))
+	admin := metadata.NewIncomingContext(ctx, metadata.Pairs(string(tenantctx.TenantAdmin), "allow"))
+	assert.Equal(t, "tenant_2", queryName(t, tenantctx.WithTenantId(admin, 2), drv, query))
+	assert.Equal(t, "default", queryName(t, tenantctx.AdminCtx(ctx), drv, query))
+
+	// the transaction is started on the tenant database
+	tx, err := drv.Tx(tenantctx.WithTenantId(ctx, 2))
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Exec(ctx, "INSERT INTO items (name) VALUES ('tx')", []any{}, nil))
+	// the ExecContext used by the ent clients generated with the sql/execquery feature
+	_, err = tx.(*tenantTx).ExecContext(ctx, "INSERT INTO items (name) VALUES (?)", "exec")
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, "tx", queryName(t, tenantctx.WithTenantId(ctx, 2), drv, "SELECT name FROM items WHERE name = 'tx'"))
+
+	// the least recently used database is closed over the limit
+	assert.Equal(t, "tenant_3", queryName(t, tenantctx.WithTenantId(ctx, 3), drv, query))
+	assert.Equal(t, 1, drv.lru.Len())
+	assert.Equal(t, "tenant_2", queryName(t, tenantctx.WithTenantId(ctx, 2), drv, query))
+	assert.Equal(t, 2, opened["tenant_2"])
+
+	// the idle databases are closed
+	drv.idleTime = time.Nanosecond
+	drv.evictIdle()
+	assert.Equal(t, 0, drv.lru.Len())
+
+	// the registry is cached until invalidated
+	registry[3] = TenantDB{DSN: "tenant_fail"}
+	assert.Equal(t, "tenant_3", queryName(t, tenantctx.WithTenantId(ctx, 3), drv, query))
+	drv.Invalidate(3)
+	rows := &entsql.Rows{}
+	assert.NotNil(t, drv.Query(tenantctx.WithTenantId(ctx, 3), query, []any{}, rows))
+}
+
+// lockCheckDriver reports whether the tenant driver is locked when it is closed.
+type lockCheckDriver struct {
+	*entsql.Driver
+	tenant *TenantDriver
+	locked *bool
+}
+
+func (d lockCheckDriver) Close() error {
+	if d.tenant.mu.TryLock() {
+		d.tenant.mu.Unlock()
+	} else {
+		*d.locked = true
+	}
+	return d.Driver.Close()
+}
+
+func TestTenantDriverTx(t *testing.T) {
+	ctx := context.Background()
+	registry := StaticTenantRegistry{2: {DSN: "tenant_tx_2"}, 3: {DSN: "tenant_tx_3"}}
+
+	var drv *TenantDriver
+	var lockedOnClose bool
+	open := func(db TenantDB) (dialect.Driver, error) {
+		return lockCheckDriver{Driver: openTestDB(t, db.DSN, db.DSN), tenant: drv, locked: &lockedOnClose}, nil
+	}
+	drv = NewTenantDriver(openTestDB(t, "tenant_tx_default", "default"), registry, open, WithMaxTenantDBs(1),
+		WithTenantIdleTime(time.Hour), WithTenantResolveTTL(time.Hour))
+	defer drv.Close()
+
+	// the database of the open transaction is not closed over the limit, the others are closed on release
+	t2 := tenantctx.WithTenantId(ctx, 2)
+	tx, err := drv.Tx(t2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, drv.dbs[TenantDB{DSN: "tenant_tx_2"}].Value.(*tenantConn).inUse)
+	assert.Equal(t, "tenant_tx_3", queryName(t, tenantctx.WithTenantId(ctx, 3), drv, "SELECT name FROM items LIMIT 1"))
+	assert.Equal(t, 1, drv.lru.Len())
+	assert.Contains(t, drv.dbs, TenantDB{DSN: "tenant_tx_2"})
+	assert.Nil(t, tx.Exec(ctx, "INSERT INTO items (name) VALUES ('tx')", []any{}, nil))
+	assert.Nil(t, tx.Commit())
+	// the database is released once
+	assert.NotNil(t, tx.Rollback())
+	assert.Equal(t, 0, drv.dbs[TenantDB{DSN: "tenant_tx_2"}].Value.(*tenantConn).inUse)
+
+	tx, err = drv.BeginTx(t2, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.Rollback())
+	assert.Equal(t, 0, drv.dbs[TenantDB{DSN: "tenant_tx_2"}].Value.(*tenantConn).inUse)
+
+	// the databases are closed without the lock
+	assert.Equal(t, "tenant_tx_3", queryName(t, tenantctx.WithTenantId(ctx, 3), drv, "SELECT name FROM items LIMIT 1"))
+	assert.Equal(t, 1, drv.lru.Len())
+	assert.Contains(t, drv.dbs, TenantDB{DSN: "tenant_tx_3"})
+	drv.idleTime = time.Nanosecond
+	drv.evictIdle()
+	assert.Equal(t, 0, drv.lru.Len())
+	assert.False(t, lockedOnClose)
+
+	// the expired databases loaded from the registry are removed
+	assert.Len(t, drv.resolved, 2)
+	drv.resolved[2] = resolvedTenant{expireAt: time.Now()}
+	drv.evictIdle()
+	assert.Len(t, drv.resolved, 1)
+	assert.Contains(t, drv.resolved, uint64(3))
+}
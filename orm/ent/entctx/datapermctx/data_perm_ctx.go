@@ -40,6 +40,9 @@ const (
 
 	// FilterFieldKey is the key to store filter field
 	FilterFieldKey DataPermKey = "data-perm-filter-field"
+
+	// IgnoreDataPermKey is the key to skip the data permission filter
+	IgnoreDataPermKey DataPermKey = "data-perm-ignore"
 )
 
 // WithScopeContext returns context with data scope
@@ -176,6 +179,18 @@ func GetFilterFieldFromCtx(ctx context.Context) (string, error) {
 	}
 }
 
+// WithIgnoreDataPerm returns context which skips the data permission filter.
+// It is only stored in context and not passed to other services.
+func WithIgnoreDataPerm(ctx context.Context) context.Context {
+	return context.WithValue(ctx, IgnoreDataPermKey, true)
+}
+
+// GetIgnoreDataPermFromCtx returns true if the data permission filter is skipped
+func GetIgnoreDataPermFromCtx(ctx context.Context) bool {
+	ignore, _ := ctx.Value(IgnoreDataPermKey).(bool)
+	return ignore
+}
+
 // GetRoleCustomDeptDataPermRedisKey returns the key to store role custom department data into redis
 func GetRoleCustomDeptDataPermRedisKey(roleCodes []string) string {
 	return fmt.Sprintf("%sROLE:%s:CustomDept", config.RedisDataPermissionPrefix, strings.Join(roleCodes, ","))
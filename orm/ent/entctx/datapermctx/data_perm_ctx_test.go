@@ -202,3 +202,42 @@ func TestGetFilterFieldFromCtx(t *testing.T) {
 		})
 	}
 }
+
+func TestGetIgnoreDataPermFromCtx(t *testing.T) {
+	if GetIgnoreDataPermFromCtx(context.Background()) {
+		t.Error("GetIgnoreDataPermFromCtx() = true, want false")
+	}
+
+	if !GetIgnoreDataPermFromCtx(WithIgnoreDataPerm(context.Background())) {
+		t.Error("GetIgnoreDataPermFromCtx() = false, want true")
+	}
+}
+
+func TestParseDeptIds(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []uint64
+		wantErr bool
+	}{
+		{name: "test empty", data: "", want: nil},
+		{name: "test ids", data: "1, 2,3", want: []uint64{1, 2, 3}},
+		{name: "test invalid", data: "1,a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDeptIds(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDeptIds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDeptIds() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := FormatDeptIds([]uint64{1, 2, 3}); got != "1,2,3" {
+		t.Errorf("FormatDeptIds() got = %v, want 1,2,3", got)
+	}
+}
@@ -0,0 +1,87 @@
+package datapermctx
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/errorx"
+	"mingyang.com/admin-common/enum/common"
+)
+
+// Loader loads the data permission which is not in context.
+type Loader interface {
+	// LoadScope returns the data scope of the roles in the tenant.
+	LoadScope(ctx context.Context, roleCodes []string, tenantId uint64) (uint8, error)
+	// LoadCustomDept returns the custom department ids of the roles in the tenant.
+	LoadCustomDept(ctx context.Context, roleCodes []string, tenantId uint64) ([]uint64, error)
+	// LoadSubDept returns the department id and its sub department ids in the tenant.
+	LoadSubDept(ctx context.Context, departmentId, tenantId uint64) ([]uint64, error)
+}
+
+// RedisLoader loads the data permission from the tenant redis keys. The scope is stored as the
+// number string and the department ids are stored as the comma separated string.
+type RedisLoader struct {
+	rds redis.UniversalClient
+}
+
+// NewRedisLoader returns the loader reading the tenant redis keys.
+func NewRedisLoader(rds redis.UniversalClient) *RedisLoader {
+	return &RedisLoader{rds: rds}
+}
+
+func (l *RedisLoader) LoadScope(ctx context.Context, roleCodes []string, tenantId uint64) (uint8, error) {
+	data, err := l.rds.Get(ctx, GetTenantRoleScopeDataPermRedisKey(roleCodes, tenantId)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	scope, err := strconv.ParseUint(data, common.Ten, 8)
+	if err != nil {
+		return 0, errorx.NewInvalidArgumentError("failed to get data scope")
+	}
+	return uint8(scope), nil
+}
+
+func (l *RedisLoader) LoadCustomDept(ctx context.Context, roleCodes []string, tenantId uint64) ([]uint64, error) {
+	data, err := l.rds.Get(ctx, GetTenantRoleCustomDeptDataPermRedisKey(roleCodes, tenantId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeptIds(data)
+}
+
+func (l *RedisLoader) LoadSubDept(ctx context.Context, departmentId, tenantId uint64) ([]uint64, error) {
+	data, err := l.rds.Get(ctx, GetTenantSubDeptDataPermRedisKey(departmentId, tenantId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeptIds(data)
+}
+
+// ParseDeptIds parses the comma separated department ids, empty string returns no ids.
+func ParseDeptIds(data string) ([]uint64, error) {
+	if data == common.EmptyString {
+		return nil, nil
+	}
+
+	var ids []uint64
+	for _, v := range strings.Split(data, common.Comma) {
+		id, err := strconv.ParseUint(strings.TrimSpace(v), common.Ten, 64)
+		if err != nil {
+			return nil, errorx.NewInvalidArgumentError("failed to parse department ids")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// FormatDeptIds formats the department ids to the comma separated string.
+func FormatDeptIds(ids []uint64) string {
+	data := make([]string, 0, len(ids))
+	for _, v := range ids {
+		data = append(data, strconv.FormatUint(v, common.Ten))
+	}
+	return strings.Join(data, common.Comma)
+}
@@ -0,0 +1,327 @@
+package datapermctx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/errorx"
+	"github.com/zeromicro/go-zero/core/logx"
+	"mingyang.com/admin-common/config"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entenum"
+)
+
+// DefaultCacheExpire is the default expiration of the data permission cache.
+const DefaultCacheExpire = 24 * time.Hour
+
+// RoleDataPerm is the data permission of a role.
+type RoleDataPerm struct {
+	// Scope is the data scope, such as entenum.DataPermAll
+	Scope uint8
+	// CustomDeptIds is the department ids of entenum.DataPermCustomDept scope
+	CustomDeptIds []uint64
+}
+
+// Source loads the data permission from the database.
+type Source interface {
+	// LoadRoleDataPerm returns the data permission of each role in the tenant, the unknown roles are ignored.
+	LoadRoleDataPerm(ctx context.Context, roleCodes []string, tenantId uint64) ([]RoleDataPerm, error)
+	// LoadChildDept returns the direct child department ids of the departments in the tenant.
+	LoadChildDept(ctx context.Context, departmentIds []uint64, tenantId uint64) ([]uint64, error)
+}
+
+// DataPerm is the resolved data permission.
+type DataPerm struct {
+	Scope         uint8
+	CustomDeptIds []uint64
+	// SubDeptIds contains the department id and all its sub department ids
+	SubDeptIds []uint64
+}
+
+// Resolver resolves the data permission from the roles and the department tree, and caches the results
+// in the tenant redis keys. It implements Loader, so it can be used by mixins.SetDataPermLoader.
+//
+// The effective scope of multiple roles is the most permissive one, which is the smallest value of
+// entenum.DataPermAll to entenum.DataPermSelf. The custom department ids of all roles with
+// entenum.DataPermCustomDept scope are merged. If none of the roles is found, the scope is entenum.DataPermSelf.
+type Resolver struct {
+	rds    redis.UniversalClient
+	source Source
+	expire time.Duration
+}
+
+var _ Loader = (*Resolver)(nil)
+
+// ResolverOption is the option of Resolver.
+type ResolverOption func(*Resolver)
+
+// WithCacheExpire sets the expiration of the cache, the default is DefaultCacheExpire.
+func WithCacheExpire(expire time.Duration) ResolverOption {
+	return func(r *Resolver) {
+		r.expire = expire
+	}
+}
+
+// NewResolver returns the data permission resolver. The cache is disabled if rds is nil.
+func NewResolver(rds redis.UniversalClient, source Source, opts ...ResolverOption) *Resolver {
+	r := &Resolver{rds: rds, source: source, expire: DefaultCacheExpire}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the data permission of the roles and the department in the tenant.
+// The sub department ids are only resolved for entenum.DataPermOwnDeptAndSub scope.
+func (r *Resolver) Resolve(ctx context.Context, roleCodes []string, departmentId, tenantId uint64) (*DataPerm, error) {
+	scope, err := r.LoadScope(ctx, roleCodes, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DataPerm{Scope: scope}
+	switch scope {
+	case entenum.DataPermCustomDept:
+		if result.CustomDeptIds, err = r.LoadCustomDept(ctx, roleCodes, tenantId); err != nil {
+			return nil, err
+		}
+	case entenum.DataPermOwnDeptAndSub:
+		if result.SubDeptIds, err = r.LoadSubDept(ctx, departmentId, tenantId); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// NewContext resolves the data permission and returns the context with the data permission,
+// which can be read by GetScopeFromCtx, GetCustomDeptFromCtx and GetSubDeptFromCtx.
+func (r *Resolver) NewContext(ctx context.Context, roleCodes []string, departmentId, tenantId uint64) (context.Context, error) {
+	perm, err := r.Resolve(ctx, roleCodes, departmentId, tenantId)
+	if err != nil {
+		return ctx, err
+	}
+
+	ctx = WithScopeContext(ctx, strconv.Itoa(int(perm.Scope)))
+	switch perm.Scope {
+	case entenum.DataPermCustomDept:
+		ctx = WithCustomDeptContext(ctx, FormatDeptIds(perm.CustomDeptIds))
+	case entenum.DataPermOwnDeptAndSub:
+		ctx = WithSubDeptContext(ctx, FormatDeptIds(perm.SubDeptIds))
+	}
+	return ctx, nil
+}
+
+// LoadScope returns the effective data scope of the roles in the tenant.
+func (r *Resolver) LoadScope(ctx context.Context, roleCodes []string, tenantId uint64) (uint8, error) {
+	roleCodes = sortedRoleCodes(roleCodes)
+	if len(roleCodes) == common.Zero {
+		return 0, errorx.NewInvalidArgumentError("failed to get data scope")
+	}
+
+	if data, ok := r.getCache(ctx, GetTenantRoleScopeDataPermRedisKey(roleCodes, tenantId)); ok {
+		if scope, err := strconv.ParseUint(data, common.Ten, 8); err == nil {
+			return uint8(scope), nil
+		}
+	}
+
+	perm, err := r.resolveRoles(ctx, roleCodes, tenantId)
+	if err != nil {
+		return 0, err
+	}
+	return perm.Scope, nil
+}
+
+// LoadCustomDept returns the merged custom department ids of the roles in the tenant.
+func (r *Resolver) LoadCustomDept(ctx context.Context, roleCodes []string, tenantId uint64) ([]uint64, error) {
+	roleCodes = sortedRoleCodes(roleCodes)
+	if len(roleCodes) == common.Zero {
+		return nil, errorx.NewInvalidArgumentError("failed to get custom department ids")
+	}
+
+	if data, ok := r.getCache(ctx, GetTenantRoleCustomDeptDataPermRedisKey(roleCodes, tenantId)); ok {
+		if ids, err := ParseDeptIds(data); err == nil {
+			return ids, nil
+		}
+	}
+
+	perm, err := r.resolveRoles(ctx, roleCodes, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	return perm.CustomDeptIds, nil
+}
+
+// LoadSubDept returns the department id and all its sub department ids in the tenant.
+func (r *Resolver) LoadSubDept(ctx context.Context, departmentId, tenantId uint64) ([]uint64, error) {
+	key := GetTenantSubDeptDataPermRedisKey(departmentId, tenantId)
+	if data, ok := r.getCache(ctx, key); ok {
+		if ids, err := ParseDeptIds(data); err == nil {
+			return ids, nil
+		}
+	}
+
+	ids := []uint64{departmentId}
+	visited := map[uint64]struct{}{departmentId: {}}
+	for parents := ids; len(parents) > common.Zero; {
+		children, err := r.source.LoadChildDept(ctx, parents, tenantId)
+		if err != nil {
+			return nil, err
+		}
+
+		parents = nil
+		for _, v := range children {
+			// 忽略重复与成环的部门
+			if _, ok := visited[v]; ok {
+				continue
+			}
+			visited[v] = struct{}{}
+			parents = append(parents, v)
+		}
+		ids = append(ids, parents...)
+	}
+
+	r.setCache(ctx, map[string]string{key: FormatDeptIds(ids)})
+	return ids, nil
+}
+
+// InvalidateRoles removes the cached data permission of all roles in the tenant,
+// it should be called after the data scope or the custom departments of a role changed.
+func (r *Resolver) InvalidateRoles(ctx context.Context, tenantId uint64) error {
+	return r.deleteByPattern(ctx, fmt.Sprintf("%s%d:ROLE:*", config.RedisDataPermissionPrefix, tenantId))
+}
+
+// InvalidateDepartments removes the cached sub departments in the tenant,
+// it should be called after a department is created, moved or deleted.
+func (r *Resolver) InvalidateDepartments(ctx context.Context, tenantId uint64) error {
+	return r.deleteByPattern(ctx, fmt.Sprintf("%s%d:DEPT:*", config.RedisDataPermissionPrefix, tenantId))
+}
+
+// InvalidateTenant removes all cached data permission in the tenant.
+func (r *Resolver) InvalidateTenant(ctx context.Context, tenantId uint64) error {
+	return r.deleteByPattern(ctx, fmt.Sprintf("%s%d:*", config.RedisDataPermissionPrefix, tenantId))
+}
+
+// resolveRoles loads the data permission of the roles from the source and caches the results.
+func (r *Resolver) resolveRoles(ctx context.Context, roleCodes []string, tenantId uint64) (*DataPerm, error) {
+	perms, err := r.source.LoadRoleDataPerm(ctx, roleCodes, tenantId)
+	if err != nil {
+		return nil, err
+	}
+
+	perm := MergeRoleDataPerm(perms)
+	r.setCache(ctx, map[string]string{
+		GetTenantRoleScopeDataPermRedisKey(roleCodes, tenantId):      strconv.Itoa(int(perm.Scope)),
+		GetTenantRoleCustomDeptDataPermRedisKey(roleCodes, tenantId): FormatDeptIds(perm.CustomDeptIds),
+	})
+	return perm, nil
+}
+
+// MergeRoleDataPerm returns the most permissive data permission of the roles.
+func MergeRoleDataPerm(perms []RoleDataPerm) *DataPerm {
+	result := &DataPerm{Scope: entenum.DataPermSelf}
+	for _, v := range perms {
+		if v.Scope >= entenum.DataPermAll && v.Scope < result.Scope {
+			result.Scope = v.Scope
+		}
+	}
+
+	if result.Scope == entenum.DataPermCustomDept {
+		for _, v := range perms {
+			if v.Scope != entenum.DataPermCustomDept {
+				continue
+			}
+			for _, id := range v.CustomDeptIds {
+				if !slices.Contains(result.CustomDeptIds, id) {
+					result.CustomDeptIds = append(result.CustomDeptIds, id)
+				}
+			}
+		}
+		slices.Sort(result.CustomDeptIds)
+	}
+
+	return result
+}
+
+func (r *Resolver) getCache(ctx context.Context, key string) (string, bool) {
+	if r.rds == nil {
+		return common.EmptyString, false
+	}
+
+	data, err := r.rds.Get(ctx, key).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logx.WithContext(ctx).Errorw("failed to get data permission from redis", logx.Field("key", key),
+				logx.Field("detail", err.Error()))
+		}
+		return common.EmptyString, false
+	}
+	return data, true
+}
+
+// setCache 缓存失败不影响结果, 只记录日志
+func (r *Resolver) setCache(ctx context.Context, values map[string]string) {
+	if r.rds == nil {
+		return
+	}
+
+	_, err := r.rds.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for k, v := range values {
+			pipe.Set(ctx, k, v, r.expire)
+		}
+		return nil
+	})
+	if err != nil {
+		logx.WithContext(ctx).Errorw("failed to set data permission to redis", logx.Field("detail", err.Error()))
+	}
+}
+
+func (r *Resolver) deleteByPattern(ctx context.Context, pattern string) error {
+	if r.rds == nil {
+		return nil
+	}
+
+	if cluster, ok := r.rds.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, client *redis.Client) error {
+			return deleteByPattern(ctx, client, pattern)
+		})
+	}
+	return deleteByPattern(ctx, r.rds, pattern)
+}
+
+func deleteByPattern(ctx context.Context, rds redis.Cmdable, pattern string) error {
+	iter := rds.Scan(ctx, 0, pattern, 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	// 集群模式下不同 key 可能不在同一 slot, 逐个删除
+	for _, key := range keys {
+		if err := rds.Del(ctx, key).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedRoleCodes returns the sorted copy of the role codes without empty codes,
+// so that the same roles in different order share the cache.
+func sortedRoleCodes(roleCodes []string) []string {
+	result := make([]string, 0, len(roleCodes))
+	for _, v := range roleCodes {
+		if v != common.EmptyString {
+			result = append(result, v)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
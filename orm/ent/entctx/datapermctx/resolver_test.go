@@ -0,0 +1,98 @@
+package datapermctx
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"mingyang.com/admin-common/orm/ent/entenum"
+)
+
+type testSource struct {
+	roles    map[string]RoleDataPerm
+	children map[uint64][]uint64
+}
+
+func (s testSource) LoadRoleDataPerm(_ context.Context, roleCodes []string, _ uint64) ([]RoleDataPerm, error) {
+	var result []RoleDataPerm
+	for _, v := range roleCodes {
+		if perm, ok := s.roles[v]; ok {
+			result = append(result, perm)
+		}
+	}
+	return result, nil
+}
+
+func (s testSource) LoadChildDept(_ context.Context, departmentIds []uint64, _ uint64) ([]uint64, error) {
+	var result []uint64
+	for _, v := range departmentIds {
+		result = append(result, s.children[v]...)
+	}
+	return result, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	r := NewResolver(nil, testSource{
+		roles: map[string]RoleDataPerm{
+			"all":     {Scope: entenum.DataPermAll},
+			"custom1": {Scope: entenum.DataPermCustomDept, CustomDeptIds: []uint64{3, 1}},
+			"custom2": {Scope: entenum.DataPermCustomDept, CustomDeptIds: []uint64{2, 3}},
+			"sub":     {Scope: entenum.DataPermOwnDeptAndSub},
+			"self":    {Scope: entenum.DataPermSelf},
+		},
+		// 5 -> 6 -> 7 -> 5 成环
+		children: map[uint64][]uint64{1: {2, 3}, 2: {4}, 5: {6}, 6: {7}, 7: {5}},
+	})
+
+	tests := []struct {
+		name         string
+		roleCodes    []string
+		departmentId uint64
+		want         *DataPerm
+		wantErr      bool
+	}{
+		{name: "test all", roleCodes: []string{"self", "all"}, want: &DataPerm{Scope: entenum.DataPermAll}},
+		{
+			name:      "test custom",
+			roleCodes: []string{"sub", "custom2", "custom1"},
+			want:      &DataPerm{Scope: entenum.DataPermCustomDept, CustomDeptIds: []uint64{1, 2, 3}},
+		},
+		{
+			name:         "test sub",
+			roleCodes:    []string{"self", "sub"},
+			departmentId: 1,
+			want:         &DataPerm{Scope: entenum.DataPermOwnDeptAndSub, SubDeptIds: []uint64{1, 2, 3, 4}},
+		},
+		{
+			name:         "test cycle",
+			roleCodes:    []string{"sub"},
+			departmentId: 5,
+			want:         &DataPerm{Scope: entenum.DataPermOwnDeptAndSub, SubDeptIds: []uint64{5, 6, 7}},
+		},
+		{name: "test unknown role", roleCodes: []string{"unknown"}, want: &DataPerm{Scope: entenum.DataPermSelf}},
+		{name: "test no role", roleCodes: []string{""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), tt.roleCodes, tt.departmentId, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Resolve() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	ctx, err := r.NewContext(context.Background(), []string{"custom1"}, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scope, _ := GetScopeFromCtx(ctx); scope != entenum.DataPermCustomDept {
+		t.Errorf("GetScopeFromCtx() got = %v, want %v", scope, entenum.DataPermCustomDept)
+	}
+	if ids, _ := GetCustomDeptFromCtx(ctx); !reflect.DeepEqual(ids, []uint64{1, 3}) {
+		t.Errorf("GetCustomDeptFromCtx() got = %v, want [1 3]", ids)
+	}
+}
@@ -17,19 +17,38 @@ package deptctx
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"strconv"
 
 	"github.com/zeromicro/go-zero/core/errorx"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/rest/enum"
 	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/enum/common"
+)
+
+type DeptKey string
+
+const (
+	// DepartmentIDsKey is the key to store the department ids which can be accessed
+	DepartmentIDsKey DeptKey = "dept-ids"
+
+	// SkipDepartmentFilterKey is the key to skip the department filter
+	SkipDepartmentFilterKey DeptKey = "dept-filter-skip"
 )
 
 // GetDepartmentIDFromCtx returns department id from context.
 func GetDepartmentIDFromCtx(ctx context.Context) (uint64, error) {
 	var departmentId string
 
-	if deptId, ok := ctx.Value("deptId").(json.Number); !ok {
+	switch deptId := ctx.Value(common.CtxKeyDeptID).(type) {
+	case json.Number:
+		departmentId = deptId.String()
+	case string:
+		departmentId = deptId
+	case uint64:
+		return deptId, nil
+	default:
 		if md, ok := metadata.FromIncomingContext(ctx); !ok {
 			logx.Error("failed to get department id from context", logx.Field("detail", ctx))
 			return 0, errorx.NewInvalidArgumentError("failed to get department ID")
@@ -40,14 +59,49 @@ func GetDepartmentIDFromCtx(ctx context.Context) (uint64, error) {
 				return 0, errorx.NewInvalidArgumentError("failed to get department ID")
 			}
 		}
-	} else {
-		departmentId = deptId.String()
 	}
 
-	id, err := strconv.Atoi(departmentId)
+	id, err := strconv.ParseUint(departmentId, common.Ten, 64)
 	if err != nil {
 		logx.Error("failed to convert department id", logx.Field("detail", err))
 		return 0, errorx.NewInvalidArgumentError("failed to get department ID")
 	}
-	return uint64(id), nil
+	return id, nil
+}
+
+// WithDepartmentIDs returns context with the department ids which can be accessed,
+// such as the own department, the sub departments and the custom departments.
+// The ids are derived by the server, so they are only stored in context and not passed to other services.
+func WithDepartmentIDs(ctx context.Context, ids ...uint64) context.Context {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	return context.WithValue(ctx, DepartmentIDsKey, ids)
+}
+
+// GetDepartmentIDsFromCtx returns the department ids which can be accessed from context.
+// If the ids are not set by WithDepartmentIDs, it returns the department id of the user.
+// The ids in the incoming metadata are ignored since they can be forged by the clients.
+func GetDepartmentIDsFromCtx(ctx context.Context) ([]uint64, error) {
+	if ids, ok := ctx.Value(DepartmentIDsKey).([]uint64); ok {
+		return ids, nil
+	}
+
+	id, err := GetDepartmentIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []uint64{id}, nil
+}
+
+// SkipDepartmentFilter returns context which skips the department filter.
+// It is only stored in context and not passed to other services.
+func SkipDepartmentFilter(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SkipDepartmentFilterKey, true)
+}
+
+// GetSkipDepartmentFilterFromCtx returns true if the department filter is skipped.
+func GetSkipDepartmentFilterFromCtx(ctx context.Context) bool {
+	skip, _ := ctx.Value(SkipDepartmentFilterKey).(bool)
+	return skip
 }
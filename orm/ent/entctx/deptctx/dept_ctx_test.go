@@ -16,7 +16,12 @@ package deptctx
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
 	"testing"
+
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestGetDepartmentIDFromCtx(t *testing.T) {
@@ -41,6 +46,18 @@ func TestGetDepartmentIDFromCtx(t *testing.T) {
 			want:    0,
 			wantErr: true,
 		},
+		{
+			name:    "test department json number",
+			args:    args{ctx: context.WithValue(context.Background(), "deptId", json.Number("3"))},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name:    "test department uint64",
+			args:    args{ctx: context.WithValue(context.Background(), "deptId", uint64(4))},
+			want:    4,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -55,3 +72,67 @@ func TestGetDepartmentIDFromCtx(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDepartmentIDsFromCtx(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    []uint64
+		wantErr bool
+	}{
+		{
+			name:    "test empty context",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+		{
+			name: "test department ids",
+			ctx:  WithDepartmentIDs(context.Background(), 3, 1, 3),
+			want: []uint64{1, 3},
+		},
+		{
+			name: "test empty department ids",
+			ctx:  WithDepartmentIDs(context.Background()),
+			want: nil,
+		},
+		{
+			name: "test forged metadata",
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				string(DepartmentIDsKey), "1,2")),
+			wantErr: true,
+		},
+		{
+			name: "test forged metadata with department id",
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				string(DepartmentIDsKey), "1,2", enum.DepartmentIdRpcCtxKey, "5")),
+			want: []uint64{5},
+		},
+		{
+			name: "test fallback to department id",
+			ctx:  context.WithValue(context.Background(), "deptId", json.Number("5")),
+			want: []uint64{5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDepartmentIDsFromCtx(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetDepartmentIDsFromCtx() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetDepartmentIDsFromCtx() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipDepartmentFilter(t *testing.T) {
+	if GetSkipDepartmentFilterFromCtx(context.Background()) {
+		t.Error("GetSkipDepartmentFilterFromCtx() = true, want false")
+	}
+
+	if !GetSkipDepartmentFilterFromCtx(SkipDepartmentFilter(context.Background())) {
+		t.Error("GetSkipDepartmentFilterFromCtx() = false, want true")
+	}
+}
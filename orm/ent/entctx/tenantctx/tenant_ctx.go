@@ -102,6 +102,12 @@ func WithIgnoreTenant(ctx context.Context) context.Context {
 	return context.WithValue(ctx, IgnoreTenantFilterKey, true)
 }
 
+// GetIgnoreTenantFromCtx 返回是否忽略租户过滤
+func GetIgnoreTenantFromCtx(ctx context.Context) bool {
+	ignore, _ := ctx.Value(IgnoreTenantFilterKey).(bool)
+	return ignore
+}
+
 // WithoutIgnoreTenant 创建恢复租户过滤的上下文
 func WithoutIgnoreTenant(ctx context.Context) context.Context {
 	return context.WithValue(ctx, IgnoreTenantFilterKey, false)
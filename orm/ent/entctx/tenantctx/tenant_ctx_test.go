@@ -122,3 +122,11 @@ func TestWithTenantIdCtx(t *testing.T) {
 
 	assert.Equal(t, uint64(12), tenantId)
 }
+
+func TestGetIgnoreTenantFromCtx(t *testing.T) {
+	assert.False(t, GetIgnoreTenantFromCtx(context.Background()))
+
+	ctx := WithIgnoreTenant(context.Background())
+	assert.True(t, GetIgnoreTenantFromCtx(ctx))
+	assert.False(t, GetIgnoreTenantFromCtx(WithoutIgnoreTenant(ctx)))
+}
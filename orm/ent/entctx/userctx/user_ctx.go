@@ -42,3 +42,20 @@ func GetUserIDFromCtx(ctx context.Context) (string, error) {
 		return userId, nil
 	}
 }
+
+type UserKey string
+
+// SystemActorKey is the key to mark the operations of the system, such as the background jobs
+const SystemActorKey UserKey = "system-actor"
+
+// WithSystemActor returns context of the system actor, which is used by the background jobs without user.
+// It is only stored in context and not passed to other services.
+func WithSystemActor(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SystemActorKey, true)
+}
+
+// IsSystemActor returns true if the context is of the system actor.
+func IsSystemActor(ctx context.Context) bool {
+	system, _ := ctx.Value(SystemActorKey).(bool)
+	return system
+}
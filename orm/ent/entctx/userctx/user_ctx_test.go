@@ -65,3 +65,13 @@ func TestGetUserIDFromCtx(t *testing.T) {
 		})
 	}
 }
+
+func TestIsSystemActor(t *testing.T) {
+	if IsSystemActor(context.Background()) {
+		t.Error("IsSystemActor() = true, want false")
+	}
+
+	if !IsSystemActor(WithSystemActor(context.Background())) {
+		t.Error("IsSystemActor() = false, want true")
+	}
+}
@@ -0,0 +1,63 @@
+package history
+
+import (
+	"context"
+	"time"
+)
+
+// Op is the operation of the history record.
+type Op string
+
+const (
+	OpCreate Op = "create"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+// Record is the change history of an entity, it is written after the mutation succeeds.
+type Record struct {
+	Id string `json:"id" bson:"_id"`
+	// Entity is the ent type name, such as User
+	Entity   string `json:"entity" bson:"entity"`
+	EntityId string `json:"entityId" bson:"entityId"`
+	Op       Op     `json:"op" bson:"op"`
+	// Before is the field values before the mutation, nil on create
+	Before map[string]any `json:"before,omitempty" bson:"before,omitempty"`
+	// After is the field values after the mutation, nil on delete
+	After     map[string]any `json:"after,omitempty" bson:"after,omitempty"`
+	UserId    string         `json:"userId,omitempty" bson:"userId,omitempty"`
+	TenantId  uint64         `json:"tenantId" bson:"tenantId"`
+	ClientIp  string         `json:"clientIp,omitempty" bson:"clientIp,omitempty"`
+	RequestId string         `json:"requestId,omitempty" bson:"requestId,omitempty"`
+	CreatedAt time.Time      `json:"createdAt" bson:"createdAt"`
+}
+
+// Sink writes the history records.
+type Sink interface {
+	Write(ctx context.Context, records []*Record) error
+}
+
+// Reader reads the history records.
+type Reader interface {
+	// List returns the history of the entity in the newest first order, limit <= 0 means no limit.
+	List(ctx context.Context, entity, entityId string, limit int) ([]*Record, error)
+}
+
+// SinkFunc is the function implementing Sink.
+type SinkFunc func(ctx context.Context, records []*Record) error
+
+func (f SinkFunc) Write(ctx context.Context, records []*Record) error {
+	return f(ctx, records)
+}
+
+// MultiSink writes the records to all sinks in order, and returns the first error.
+func MultiSink(sinks ...Sink) Sink {
+	return SinkFunc(func(ctx context.Context, records []*Record) error {
+		for _, v := range sinks {
+			if err := v.Write(ctx, records); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,116 @@
+package history_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+	"mingyang.com/admin-common/orm/ent/history"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/enttest"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/historyitem"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// openHistoryClient returns the client and the sink on the same sqlite file, the short busy timeout
+// fails the writes out of the transaction quickly.
+func openHistoryClient(t *testing.T) (*ent.Client, *history.SQLSink) {
+	drv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?_fk=1&_busy_timeout=50",
+		filepath.Join(t.TempDir(), "history.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	sink := history.NewSQLSink(drv, "")
+	if err = sink.CreateTable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	mixins.SetHistorySink(sink)
+	t.Cleanup(func() {
+		mixins.SetHistorySink(nil)
+	})
+	return client, sink
+}
+
+func listHistory(t *testing.T, sink *history.SQLSink, id uint64) []*history.Record {
+	records, err := sink.List(context.Background(), "HistoryItem", fmt.Sprint(id), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestHistoryMixinHooks(t *testing.T) {
+	client, sink := openHistoryClient(t)
+	ctx := context.WithValue(context.Background(), common.CtxKeyUserID, "u1")
+
+	item := client.HistoryItem.Create().SetName("a").SaveX(ctx)
+	client.HistoryItem.UpdateOne(item).SetName("b").ExecX(ctx)
+	// the update which matches nothing is not recorded
+	client.HistoryItem.Update().Where(historyitem.Name("x")).SetName("c").ExecX(ctx)
+	client.HistoryItem.DeleteOne(item).ExecX(userctx.WithSystemActor(ctx))
+
+	records := listHistory(t, sink, item.ID)
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+	// the newest first
+	deleted, updated, created := records[0], records[1], records[2]
+	if created.Op != history.OpCreate || created.Before != nil || created.After["name"] != "a" ||
+		created.UserId != "u1" {
+		t.Errorf("unexpected create record %+v", created)
+	}
+	if updated.Op != history.OpUpdate || updated.Before["name"] != "a" || updated.After["name"] != "b" {
+		t.Errorf("unexpected update record %+v", updated)
+	}
+	if deleted.Op != history.OpDelete || deleted.Before["name"] != "b" || deleted.After != nil ||
+		deleted.UserId != "system" {
+		t.Errorf("unexpected delete record %+v", deleted)
+	}
+}
+
+func TestHistoryMixinTx(t *testing.T) {
+	client, sink := openHistoryClient(t)
+	ctx := context.Background()
+
+	// the records are written by the transaction instead of waiting for its lock
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committed := tx.HistoryItem.Create().SetName("a").SaveX(ctx)
+	tx.HistoryItem.UpdateOne(committed).SetName("b").ExecX(ctx)
+	if err = tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if records := listHistory(t, sink, committed.ID); len(records) != 2 {
+		t.Errorf("got %d records, want 2", len(records))
+	}
+
+	// the records are rolled back with the transaction
+	tx, err = client.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.HistoryItem.UpdateOne(committed).SetName("c").ExecX(ctx)
+	rolledBack := tx.HistoryItem.Create().SetName("d").SaveX(ctx)
+	if err = tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if records := listHistory(t, sink, committed.ID); len(records) != 2 {
+		t.Errorf("got %d records, want 2", len(records))
+	}
+	if records := listHistory(t, sink, rolledBack.ID); len(records) != 0 {
+		t.Errorf("got %d records, want 0", len(records))
+	}
+}
@@ -0,0 +1,175 @@
+package history
+
+import (
+	"context"
+	stdsql "database/sql"
+	"encoding/json"
+	"fmt"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"mingyang.com/admin-common/enum/common"
+)
+
+// DefaultTable is the default table name of SQLSink.
+const DefaultTable = "sys_entity_histories"
+
+var historyColumns = []string{"id", "entity", "entity_id", "op", "before_data", "after_data", "user_id",
+	"tenant_id", "client_ip", "request_id", "created_at"}
+
+type execerKey struct{}
+
+// Execer executes the statements, such as the ent clients and mutations generated with the
+// sql/execquery feature.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error)
+}
+
+// WithExecer returns the context in which SQLSink writes the records by ex instead of its own driver.
+// HistoryMixin sets the mutation running in a transaction, so the records are committed or rolled back
+// with the mutation.
+func WithExecer(ctx context.Context, ex Execer) context.Context {
+	return context.WithValue(ctx, execerKey{}, ex)
+}
+
+// SQLSink writes the history records to a table of the database, and implements Reader.
+//
+// The records of the mutations in a transaction are written by the transaction set by WithExecer,
+// so the table must be in the database of the entities.
+type SQLSink struct {
+	drv   dialect.Driver
+	table string
+}
+
+// NewSQLSink returns the sink writing to the table, the default table is DefaultTable.
+// Call CreateTable to create the table if it is not managed by the migration.
+func NewSQLSink(drv dialect.Driver, table string) *SQLSink {
+	if table == common.EmptyString {
+		table = DefaultTable
+	}
+	return &SQLSink{drv: drv, table: table}
+}
+
+// CreateTable creates the history table and its index if they do not exist.
+func (s *SQLSink) CreateTable(ctx context.Context) error {
+	var stmts []string
+	switch s.drv.Dialect() {
+	case dialect.MySQL:
+		stmts = append(stmts, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id varchar(36) NOT NULL, "+
+			"entity varchar(128) NOT NULL, entity_id varchar(64) NOT NULL, op varchar(16) NOT NULL, "+
+			"before_data longtext NULL, after_data longtext NULL, user_id varchar(64) NOT NULL, "+
+			"tenant_id bigint unsigned NOT NULL, client_ip varchar(64) NOT NULL, request_id varchar(64) NOT NULL, "+
+			"created_at datetime(3) NOT NULL, PRIMARY KEY (id), INDEX %s_entity (entity, entity_id, created_at))",
+			s.table, s.table))
+	case dialect.Postgres:
+		stmts = append(stmts, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id varchar(36) PRIMARY KEY, "+
+			"entity varchar(128) NOT NULL, entity_id varchar(64) NOT NULL, op varchar(16) NOT NULL, "+
+			"before_data text NULL, after_data text NULL, user_id varchar(64) NOT NULL, "+
+			"tenant_id bigint NOT NULL, client_ip varchar(64) NOT NULL, request_id varchar(64) NOT NULL, "+
+			"created_at timestamp with time zone NOT NULL)", s.table),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_entity ON %s (entity, entity_id, created_at)", s.table, s.table))
+	case dialect.SQLite:
+		stmts = append(stmts, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id text PRIMARY KEY, "+
+			"entity text NOT NULL, entity_id text NOT NULL, op text NOT NULL, before_data text NULL, "+
+			"after_data text NULL, user_id text NOT NULL, tenant_id integer NOT NULL, client_ip text NOT NULL, "+
+			"request_id text NOT NULL, created_at datetime NOT NULL)", s.table),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_entity ON %s (entity, entity_id, created_at)", s.table, s.table))
+	default:
+		return fmt.Errorf("unsupported dialect %q", s.drv.Dialect())
+	}
+
+	for _, v := range stmts {
+		if err := s.drv.Exec(ctx, v, []any{}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write inserts the records in one statement, by the Execer in context if it is set by WithExecer.
+func (s *SQLSink) Write(ctx context.Context, records []*Record) error {
+	if len(records) == common.Zero {
+		return nil
+	}
+
+	insert := entsql.Dialect(s.drv.Dialect()).Insert(s.table).Columns(historyColumns...)
+	for _, v := range records {
+		before, err := marshalData(v.Before)
+		if err != nil {
+			return err
+		}
+		after, err := marshalData(v.After)
+		if err != nil {
+			return err
+		}
+
+		insert.Values(v.Id, v.Entity, v.EntityId, string(v.Op), before, after, v.UserId, v.TenantId, v.ClientIp,
+			v.RequestId, v.CreatedAt)
+	}
+
+	query, args := insert.Query()
+	if ex, ok := ctx.Value(execerKey{}).(Execer); ok {
+		_, err := ex.ExecContext(ctx, query, args...)
+		return err
+	}
+	return s.drv.Exec(ctx, query, args, nil)
+}
+
+// List returns the history of the entity in the newest first order, limit <= 0 means no limit.
+func (s *SQLSink) List(ctx context.Context, entity, entityId string, limit int) ([]*Record, error) {
+	selector := entsql.Dialect(s.drv.Dialect()).Select(historyColumns...).From(entsql.Table(s.table)).
+		Where(entsql.And(entsql.EQ("entity", entity), entsql.EQ("entity_id", entityId))).
+		OrderBy(entsql.Desc("created_at"), entsql.Desc("id"))
+	if limit > 0 {
+		selector.Limit(limit)
+	}
+
+	query, args := selector.Query()
+	rows := &entsql.Rows{}
+	if err := s.drv.Query(ctx, query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Record
+	for rows.Next() {
+		var (
+			v             Record
+			op            string
+			before, after entsql.NullString
+		)
+		if err := rows.Scan(&v.Id, &v.Entity, &v.EntityId, &op, &before, &after, &v.UserId, &v.TenantId,
+			&v.ClientIp, &v.RequestId, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		v.Op = Op(op)
+		if err := unmarshalData(before, &v.Before); err != nil {
+			return nil, err
+		}
+		if err := unmarshalData(after, &v.After); err != nil {
+			return nil, err
+		}
+		result = append(result, &v)
+	}
+	return result, rows.Err()
+}
+
+func marshalData(data map[string]any) (any, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func unmarshalData(data entsql.NullString, v *map[string]any) error {
+	if !data.Valid {
+		return nil
+	}
+	return json.Unmarshal([]byte(data.String), v)
+}
@@ -0,0 +1,62 @@
+package history
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestSQLSink(t *testing.T) {
+	drv, err := entsql.Open(dialect.SQLite, "file:history?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer drv.Close()
+
+	ctx := context.Background()
+	sink := NewSQLSink(drv, "")
+	if err = sink.CreateTable(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	records := []*Record{
+		{Id: "1", Entity: "User", EntityId: "1", Op: OpCreate, After: map[string]any{"name": "a"},
+			UserId: "admin", TenantId: 1, CreatedAt: now},
+		{Id: "2", Entity: "User", EntityId: "1", Op: OpUpdate, Before: map[string]any{"name": "a"},
+			After: map[string]any{"name": "b"}, TenantId: 1, ClientIp: "127.0.0.1", CreatedAt: now.Add(time.Second)},
+		{Id: "3", Entity: "User", EntityId: "2", Op: OpDelete, Before: map[string]any{"name": "c"},
+			TenantId: 1, CreatedAt: now},
+	}
+	if err = sink.Write(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := sink.List(ctx, "User", "1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Id != "2" || got[1].Id != "1" {
+		t.Fatalf("List() got %d records, want records 2 and 1", len(got))
+	}
+	if !reflect.DeepEqual(got[0].Before, records[1].Before) || !reflect.DeepEqual(got[0].After, records[1].After) {
+		t.Errorf("List() got before %v after %v, want before %v after %v", got[0].Before, got[0].After,
+			records[1].Before, records[1].After)
+	}
+	if got[1].Before != nil || got[1].Op != OpCreate || got[1].UserId != "admin" || !got[1].CreatedAt.Equal(now) {
+		t.Errorf("List() got %+v, want %+v", got[1], records[0])
+	}
+
+	got, err = sink.List(ctx, "User", "1", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Id != "2" {
+		t.Errorf("List() with limit got %d records, want record 2", len(got))
+	}
+}
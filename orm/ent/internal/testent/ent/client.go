@@ -0,0 +1,1377 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/migrate"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/audititem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/datapermitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/departmentitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/historyitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/softdeleteitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/tenantitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/uuidaudititem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/versionitem"
+
+	stdsql "database/sql"
+)
+
+// Client is the client that holds all ent builders.
+type Client struct {
+	config
+	// Schema is the client for creating, migrating and dropping schema.
+	Schema *migrate.Schema
+	// TenantItem is the client for interacting with the TenantItem builders.
+	TenantItem *TenantItemClient
+	// DepartmentItem is the client for interacting with the DepartmentItem builders.
+	DepartmentItem *DepartmentItemClient
+	// HistoryItem is the client for interacting with the HistoryItem builders.
+	HistoryItem *HistoryItemClient
+	// SoftDeleteItem is the client for interacting with the SoftDeleteItem builders.
+	SoftDeleteItem *SoftDeleteItemClient
+	// AuditItem is the client for interacting with the AuditItem builders.
+	AuditItem *AuditItemClient
+	// DataPermItem is the client for interacting with the DataPermItem builders.
+	DataPermItem *DataPermItemClient
+	// VersionItem is the client for interacting with the VersionItem builders.
+	VersionItem *VersionItemClient
+	// UUIDAuditItem is the client for interacting with the UUIDAuditItem builders.
+	UUIDAuditItem *UUIDAuditItemClient
+}
+
+// NewClient creates a new client configured with the given options.
+func NewClient(opts ...Option) *Client {
+	client := &Client{config: newConfig(opts...)}
+	client.init()
+	return client
+}
+
+func (c *Client) init() {
+	c.Schema = migrate.NewSchema(c.driver)
+	c.TenantItem = NewTenantItemClient(c.config)
+	c.DepartmentItem = NewDepartmentItemClient(c.config)
+	c.HistoryItem = NewHistoryItemClient(c.config)
+	c.SoftDeleteItem = NewSoftDeleteItemClient(c.config)
+	c.AuditItem = NewAuditItemClient(c.config)
+	c.DataPermItem = NewDataPermItemClient(c.config)
+	c.VersionItem = NewVersionItemClient(c.config)
+	c.UUIDAuditItem = NewUUIDAuditItemClient(c.config)
+}
+
+type (
+	// config is the configuration for the client and its builder.
+	config struct {
+		// driver used for executing database requests.
+		driver dialect.Driver
+		// debug enable a debug logging.
+		debug bool
+		// log used for logging on debug mode.
+		log func(...any)
+		// hooks to execute on mutations.
+		hooks *hooks
+		// interceptors to execute on queries.
+		inters *inters
+	}
+	// Option function to configure the client.
+	Option func(*config)
+)
+
+// newConfig creates a new config for the client.
+func newConfig(opts ...Option) config {
+	cfg := config{log: log.Println, hooks: &hooks{}, inters: &inters{}}
+	cfg.options(opts...)
+	return cfg
+}
+
+// options applies the options on the config object.
+func (c *config) options(opts ...Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.debug {
+		c.driver = dialect.Debug(c.driver, c.log)
+	}
+}
+
+// Debug enables debug logging on the ent.Driver.
+func Debug() Option {
+	return func(c *config) {
+		c.debug = true
+	}
+}
+
+// Log sets the logging function for debug mode.
+func Log(fn func(...any)) Option {
+	return func(c *config) {
+		c.log = fn
+	}
+}
+
+// Driver configures the client driver.
+func Driver(driver dialect.Driver) Option {
+	return func(c *config) {
+		c.driver = driver
+	}
+}
+
+// Open opens a database/sql.DB specified by the driver name and
+// the data source name, and returns a new client attached to it.
+// Optional parameters can be added for configuring the client.
+func Open(driverName, dataSourceName string, options ...Option) (*Client, error) {
+	switch driverName {
+	case dialect.MySQL, dialect.Postgres, dialect.SQLite:
+		drv, err := sql.Open(driverName, dataSourceName)
+		if err != nil {
+			return nil, err
+		}
+		return NewClient(append(options, Driver(drv))...), nil
+	default:
+		return nil, fmt.Errorf("unsupported driver: %q", driverName)
+	}
+}
+
+// ErrTxStarted is returned when trying to start a new transaction from a transactional client.
+var ErrTxStarted = errors.New("ent: cannot start a transaction within a transaction")
+
+// Tx returns a new transactional client. The provided context
+// is used until the transaction is committed or rolled back.
+func (c *Client) Tx(ctx context.Context) (*Tx, error) {
+	if _, ok := c.driver.(*txDriver); ok {
+		return nil, ErrTxStarted
+	}
+	tx, err := newTx(ctx, c.driver)
+	if err != nil {
+		return nil, fmt.Errorf("ent: starting a transaction: %w", err)
+	}
+	cfg := c.config
+	cfg.driver = tx
+	return &Tx{
+		ctx:            ctx,
+		config:         cfg,
+		TenantItem:     NewTenantItemClient(cfg),
+		DepartmentItem: NewDepartmentItemClient(cfg),
+		HistoryItem:    NewHistoryItemClient(cfg),
+		SoftDeleteItem: NewSoftDeleteItemClient(cfg),
+		AuditItem:      NewAuditItemClient(cfg),
+		DataPermItem:   NewDataPermItemClient(cfg),
+		VersionItem:    NewVersionItemClient(cfg),
+		UUIDAuditItem:  NewUUIDAuditItemClient(cfg),
+	}, nil
+}
+
+// BeginTx returns a transactional client with specified options.
+func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	if _, ok := c.driver.(*txDriver); ok {
+		return nil, errors.New("ent: cannot start a transaction within a transaction")
+	}
+	tx, err := c.driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	}).BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ent: starting a transaction: %w", err)
+	}
+	cfg := c.config
+	cfg.driver = &txDriver{tx: tx, drv: c.driver}
+	return &Tx{
+		ctx:            ctx,
+		config:         cfg,
+		TenantItem:     NewTenantItemClient(cfg),
+		DepartmentItem: NewDepartmentItemClient(cfg),
+		HistoryItem:    NewHistoryItemClient(cfg),
+		SoftDeleteItem: NewSoftDeleteItemClient(cfg),
+		AuditItem:      NewAuditItemClient(cfg),
+		DataPermItem:   NewDataPermItemClient(cfg),
+		VersionItem:    NewVersionItemClient(cfg),
+		UUIDAuditItem:  NewUUIDAuditItemClient(cfg),
+	}, nil
+}
+
+// Debug returns a new debug-client. It's used to get verbose logging on specific operations.
+//
+//	client.Debug().
+//		TenantItem.
+//		Query().
+//		Count(ctx)
+func (c *Client) Debug() *Client {
+	if c.debug {
+		return c
+	}
+	cfg := c.config
+	cfg.driver = dialect.Debug(c.driver, c.log)
+	client := &Client{config: cfg}
+	client.init()
+	return client
+}
+
+// Close closes the database connection and prevents new queries from starting.
+func (c *Client) Close() error {
+	return c.driver.Close()
+}
+
+// Use adds the mutation hooks to all the entity clients.
+// In order to add hooks to a specific client, call: `client.Node.Use(...)`.
+func (c *Client) Use(hooks ...Hook) {
+	for _, n := range []interface{ Use(...Hook) }{
+		c.TenantItem, c.DepartmentItem, c.HistoryItem, c.SoftDeleteItem, c.AuditItem,
+		c.DataPermItem, c.VersionItem, c.UUIDAuditItem,
+	} {
+		n.Use(hooks...)
+	}
+}
+
+// Intercept adds the query interceptors to all the entity clients.
+// In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
+func (c *Client) Intercept(interceptors ...Interceptor) {
+	for _, n := range []interface{ Intercept(...Interceptor) }{
+		c.TenantItem, c.DepartmentItem, c.HistoryItem, c.SoftDeleteItem, c.AuditItem,
+		c.DataPermItem, c.VersionItem, c.UUIDAuditItem,
+	} {
+		n.Intercept(interceptors...)
+	}
+}
+
+// Mutate implements the ent.Mutator interface.
+func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
+	switch m := m.(type) {
+	case *TenantItemMutation:
+		return c.TenantItem.mutate(ctx, m)
+	case *DepartmentItemMutation:
+		return c.DepartmentItem.mutate(ctx, m)
+	case *HistoryItemMutation:
+		return c.HistoryItem.mutate(ctx, m)
+	case *SoftDeleteItemMutation:
+		return c.SoftDeleteItem.mutate(ctx, m)
+	case *AuditItemMutation:
+		return c.AuditItem.mutate(ctx, m)
+	case *DataPermItemMutation:
+		return c.DataPermItem.mutate(ctx, m)
+	case *VersionItemMutation:
+		return c.VersionItem.mutate(ctx, m)
+	case *UUIDAuditItemMutation:
+		return c.UUIDAuditItem.mutate(ctx, m)
+	default:
+		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
+	}
+}
+
+// TenantItemClient is a client for the TenantItem schema.
+type TenantItemClient struct {
+	config
+}
+
+// NewTenantItemClient returns a client for the TenantItem from the given config.
+func NewTenantItemClient(c config) *TenantItemClient {
+	return &TenantItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tenantitem.Hooks(f(g(h())))`.
+func (c *TenantItemClient) Use(hooks ...Hook) {
+	c.hooks.TenantItem = append(c.hooks.TenantItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tenantitem.Intercept(f(g(h())))`.
+func (c *TenantItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.TenantItem = append(c.inters.TenantItem, interceptors...)
+}
+
+// Create returns a builder for creating a TenantItem entity.
+func (c *TenantItemClient) Create() *TenantItemCreate {
+	mutation := newTenantItemMutation(c.config, OpCreate)
+	return &TenantItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of TenantItem entities.
+func (c *TenantItemClient) CreateBulk(builders ...*TenantItemCreate) *TenantItemCreateBulk {
+	return &TenantItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *TenantItemClient) MapCreateBulk(slice any, setFunc func(*TenantItemCreate, int)) *TenantItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &TenantItemCreateBulk{err: fmt.Errorf("calling to TenantItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*TenantItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &TenantItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for TenantItem.
+func (c *TenantItemClient) Update() *TenantItemUpdate {
+	mutation := newTenantItemMutation(c.config, OpUpdate)
+	return &TenantItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *TenantItemClient) UpdateOne(_m *TenantItem) *TenantItemUpdateOne {
+	mutation := newTenantItemMutation(c.config, OpUpdateOne, withTenantItem(_m))
+	return &TenantItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *TenantItemClient) UpdateOneID(id uint64) *TenantItemUpdateOne {
+	mutation := newTenantItemMutation(c.config, OpUpdateOne, withTenantItemID(id))
+	return &TenantItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for TenantItem.
+func (c *TenantItemClient) Delete() *TenantItemDelete {
+	mutation := newTenantItemMutation(c.config, OpDelete)
+	return &TenantItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *TenantItemClient) DeleteOne(_m *TenantItem) *TenantItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *TenantItemClient) DeleteOneID(id uint64) *TenantItemDeleteOne {
+	builder := c.Delete().Where(tenantitem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &TenantItemDeleteOne{builder}
+}
+
+// Query returns a query builder for TenantItem.
+func (c *TenantItemClient) Query() *TenantItemQuery {
+	return &TenantItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeTenantItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a TenantItem entity by its id.
+func (c *TenantItemClient) Get(ctx context.Context, id uint64) (*TenantItem, error) {
+	return c.Query().Where(tenantitem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *TenantItemClient) GetX(ctx context.Context, id uint64) *TenantItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *TenantItemClient) Hooks() []Hook {
+	hooks := c.hooks.TenantItem
+	return append(hooks[:len(hooks):len(hooks)], tenantitem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *TenantItemClient) Interceptors() []Interceptor {
+	inters := c.inters.TenantItem
+	return append(inters[:len(inters):len(inters)], tenantitem.Interceptors[:]...)
+}
+
+func (c *TenantItemClient) mutate(ctx context.Context, m *TenantItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&TenantItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&TenantItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&TenantItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&TenantItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown TenantItem mutation op: %q", m.Op())
+	}
+}
+
+// DepartmentItemClient is a client for the DepartmentItem schema.
+type DepartmentItemClient struct {
+	config
+}
+
+// NewDepartmentItemClient returns a client for the DepartmentItem from the given config.
+func NewDepartmentItemClient(c config) *DepartmentItemClient {
+	return &DepartmentItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `departmentitem.Hooks(f(g(h())))`.
+func (c *DepartmentItemClient) Use(hooks ...Hook) {
+	c.hooks.DepartmentItem = append(c.hooks.DepartmentItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `departmentitem.Intercept(f(g(h())))`.
+func (c *DepartmentItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.DepartmentItem = append(c.inters.DepartmentItem, interceptors...)
+}
+
+// Create returns a builder for creating a DepartmentItem entity.
+func (c *DepartmentItemClient) Create() *DepartmentItemCreate {
+	mutation := newDepartmentItemMutation(c.config, OpCreate)
+	return &DepartmentItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of DepartmentItem entities.
+func (c *DepartmentItemClient) CreateBulk(builders ...*DepartmentItemCreate) *DepartmentItemCreateBulk {
+	return &DepartmentItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *DepartmentItemClient) MapCreateBulk(slice any, setFunc func(*DepartmentItemCreate, int)) *DepartmentItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &DepartmentItemCreateBulk{err: fmt.Errorf("calling to DepartmentItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*DepartmentItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &DepartmentItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for DepartmentItem.
+func (c *DepartmentItemClient) Update() *DepartmentItemUpdate {
+	mutation := newDepartmentItemMutation(c.config, OpUpdate)
+	return &DepartmentItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *DepartmentItemClient) UpdateOne(_m *DepartmentItem) *DepartmentItemUpdateOne {
+	mutation := newDepartmentItemMutation(c.config, OpUpdateOne, withDepartmentItem(_m))
+	return &DepartmentItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *DepartmentItemClient) UpdateOneID(id uint64) *DepartmentItemUpdateOne {
+	mutation := newDepartmentItemMutation(c.config, OpUpdateOne, withDepartmentItemID(id))
+	return &DepartmentItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for DepartmentItem.
+func (c *DepartmentItemClient) Delete() *DepartmentItemDelete {
+	mutation := newDepartmentItemMutation(c.config, OpDelete)
+	return &DepartmentItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *DepartmentItemClient) DeleteOne(_m *DepartmentItem) *DepartmentItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *DepartmentItemClient) DeleteOneID(id uint64) *DepartmentItemDeleteOne {
+	builder := c.Delete().Where(departmentitem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &DepartmentItemDeleteOne{builder}
+}
+
+// Query returns a query builder for DepartmentItem.
+func (c *DepartmentItemClient) Query() *DepartmentItemQuery {
+	return &DepartmentItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeDepartmentItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a DepartmentItem entity by its id.
+func (c *DepartmentItemClient) Get(ctx context.Context, id uint64) (*DepartmentItem, error) {
+	return c.Query().Where(departmentitem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *DepartmentItemClient) GetX(ctx context.Context, id uint64) *DepartmentItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *DepartmentItemClient) Hooks() []Hook {
+	return c.hooks.DepartmentItem
+}
+
+// Interceptors returns the client interceptors.
+func (c *DepartmentItemClient) Interceptors() []Interceptor {
+	inters := c.inters.DepartmentItem
+	return append(inters[:len(inters):len(inters)], departmentitem.Interceptors[:]...)
+}
+
+func (c *DepartmentItemClient) mutate(ctx context.Context, m *DepartmentItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&DepartmentItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&DepartmentItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&DepartmentItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&DepartmentItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown DepartmentItem mutation op: %q", m.Op())
+	}
+}
+
+// HistoryItemClient is a client for the HistoryItem schema.
+type HistoryItemClient struct {
+	config
+}
+
+// NewHistoryItemClient returns a client for the HistoryItem from the given config.
+func NewHistoryItemClient(c config) *HistoryItemClient {
+	return &HistoryItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `historyitem.Hooks(f(g(h())))`.
+func (c *HistoryItemClient) Use(hooks ...Hook) {
+	c.hooks.HistoryItem = append(c.hooks.HistoryItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `historyitem.Intercept(f(g(h())))`.
+func (c *HistoryItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.HistoryItem = append(c.inters.HistoryItem, interceptors...)
+}
+
+// Create returns a builder for creating a HistoryItem entity.
+func (c *HistoryItemClient) Create() *HistoryItemCreate {
+	mutation := newHistoryItemMutation(c.config, OpCreate)
+	return &HistoryItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of HistoryItem entities.
+func (c *HistoryItemClient) CreateBulk(builders ...*HistoryItemCreate) *HistoryItemCreateBulk {
+	return &HistoryItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *HistoryItemClient) MapCreateBulk(slice any, setFunc func(*HistoryItemCreate, int)) *HistoryItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &HistoryItemCreateBulk{err: fmt.Errorf("calling to HistoryItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*HistoryItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &HistoryItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for HistoryItem.
+func (c *HistoryItemClient) Update() *HistoryItemUpdate {
+	mutation := newHistoryItemMutation(c.config, OpUpdate)
+	return &HistoryItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *HistoryItemClient) UpdateOne(_m *HistoryItem) *HistoryItemUpdateOne {
+	mutation := newHistoryItemMutation(c.config, OpUpdateOne, withHistoryItem(_m))
+	return &HistoryItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *HistoryItemClient) UpdateOneID(id uint64) *HistoryItemUpdateOne {
+	mutation := newHistoryItemMutation(c.config, OpUpdateOne, withHistoryItemID(id))
+	return &HistoryItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for HistoryItem.
+func (c *HistoryItemClient) Delete() *HistoryItemDelete {
+	mutation := newHistoryItemMutation(c.config, OpDelete)
+	return &HistoryItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *HistoryItemClient) DeleteOne(_m *HistoryItem) *HistoryItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *HistoryItemClient) DeleteOneID(id uint64) *HistoryItemDeleteOne {
+	builder := c.Delete().Where(historyitem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &HistoryItemDeleteOne{builder}
+}
+
+// Query returns a query builder for HistoryItem.
+func (c *HistoryItemClient) Query() *HistoryItemQuery {
+	return &HistoryItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeHistoryItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a HistoryItem entity by its id.
+func (c *HistoryItemClient) Get(ctx context.Context, id uint64) (*HistoryItem, error) {
+	return c.Query().Where(historyitem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *HistoryItemClient) GetX(ctx context.Context, id uint64) *HistoryItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *HistoryItemClient) Hooks() []Hook {
+	hooks := c.hooks.HistoryItem
+	return append(hooks[:len(hooks):len(hooks)], historyitem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *HistoryItemClient) Interceptors() []Interceptor {
+	return c.inters.HistoryItem
+}
+
+func (c *HistoryItemClient) mutate(ctx context.Context, m *HistoryItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&HistoryItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&HistoryItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&HistoryItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&HistoryItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown HistoryItem mutation op: %q", m.Op())
+	}
+}
+
+// SoftDeleteItemClient is a client for the SoftDeleteItem schema.
+type SoftDeleteItemClient struct {
+	config
+}
+
+// NewSoftDeleteItemClient returns a client for the SoftDeleteItem from the given config.
+func NewSoftDeleteItemClient(c config) *SoftDeleteItemClient {
+	return &SoftDeleteItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `softdeleteitem.Hooks(f(g(h())))`.
+func (c *SoftDeleteItemClient) Use(hooks ...Hook) {
+	c.hooks.SoftDeleteItem = append(c.hooks.SoftDeleteItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `softdeleteitem.Intercept(f(g(h())))`.
+func (c *SoftDeleteItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.SoftDeleteItem = append(c.inters.SoftDeleteItem, interceptors...)
+}
+
+// Create returns a builder for creating a SoftDeleteItem entity.
+func (c *SoftDeleteItemClient) Create() *SoftDeleteItemCreate {
+	mutation := newSoftDeleteItemMutation(c.config, OpCreate)
+	return &SoftDeleteItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of SoftDeleteItem entities.
+func (c *SoftDeleteItemClient) CreateBulk(builders ...*SoftDeleteItemCreate) *SoftDeleteItemCreateBulk {
+	return &SoftDeleteItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SoftDeleteItemClient) MapCreateBulk(slice any, setFunc func(*SoftDeleteItemCreate, int)) *SoftDeleteItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SoftDeleteItemCreateBulk{err: fmt.Errorf("calling to SoftDeleteItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SoftDeleteItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SoftDeleteItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for SoftDeleteItem.
+func (c *SoftDeleteItemClient) Update() *SoftDeleteItemUpdate {
+	mutation := newSoftDeleteItemMutation(c.config, OpUpdate)
+	return &SoftDeleteItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SoftDeleteItemClient) UpdateOne(_m *SoftDeleteItem) *SoftDeleteItemUpdateOne {
+	mutation := newSoftDeleteItemMutation(c.config, OpUpdateOne, withSoftDeleteItem(_m))
+	return &SoftDeleteItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SoftDeleteItemClient) UpdateOneID(id uint64) *SoftDeleteItemUpdateOne {
+	mutation := newSoftDeleteItemMutation(c.config, OpUpdateOne, withSoftDeleteItemID(id))
+	return &SoftDeleteItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for SoftDeleteItem.
+func (c *SoftDeleteItemClient) Delete() *SoftDeleteItemDelete {
+	mutation := newSoftDeleteItemMutation(c.config, OpDelete)
+	return &SoftDeleteItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SoftDeleteItemClient) DeleteOne(_m *SoftDeleteItem) *SoftDeleteItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SoftDeleteItemClient) DeleteOneID(id uint64) *SoftDeleteItemDeleteOne {
+	builder := c.Delete().Where(softdeleteitem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SoftDeleteItemDeleteOne{builder}
+}
+
+// Query returns a query builder for SoftDeleteItem.
+func (c *SoftDeleteItemClient) Query() *SoftDeleteItemQuery {
+	return &SoftDeleteItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSoftDeleteItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a SoftDeleteItem entity by its id.
+func (c *SoftDeleteItemClient) Get(ctx context.Context, id uint64) (*SoftDeleteItem, error) {
+	return c.Query().Where(softdeleteitem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SoftDeleteItemClient) GetX(ctx context.Context, id uint64) *SoftDeleteItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *SoftDeleteItemClient) Hooks() []Hook {
+	hooks := c.hooks.SoftDeleteItem
+	return append(hooks[:len(hooks):len(hooks)], softdeleteitem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *SoftDeleteItemClient) Interceptors() []Interceptor {
+	inters := c.inters.SoftDeleteItem
+	return append(inters[:len(inters):len(inters)], softdeleteitem.Interceptors[:]...)
+}
+
+func (c *SoftDeleteItemClient) mutate(ctx context.Context, m *SoftDeleteItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SoftDeleteItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SoftDeleteItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SoftDeleteItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SoftDeleteItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown SoftDeleteItem mutation op: %q", m.Op())
+	}
+}
+
+// AuditItemClient is a client for the AuditItem schema.
+type AuditItemClient struct {
+	config
+}
+
+// NewAuditItemClient returns a client for the AuditItem from the given config.
+func NewAuditItemClient(c config) *AuditItemClient {
+	return &AuditItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `audititem.Hooks(f(g(h())))`.
+func (c *AuditItemClient) Use(hooks ...Hook) {
+	c.hooks.AuditItem = append(c.hooks.AuditItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `audititem.Intercept(f(g(h())))`.
+func (c *AuditItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.AuditItem = append(c.inters.AuditItem, interceptors...)
+}
+
+// Create returns a builder for creating a AuditItem entity.
+func (c *AuditItemClient) Create() *AuditItemCreate {
+	mutation := newAuditItemMutation(c.config, OpCreate)
+	return &AuditItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of AuditItem entities.
+func (c *AuditItemClient) CreateBulk(builders ...*AuditItemCreate) *AuditItemCreateBulk {
+	return &AuditItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *AuditItemClient) MapCreateBulk(slice any, setFunc func(*AuditItemCreate, int)) *AuditItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &AuditItemCreateBulk{err: fmt.Errorf("calling to AuditItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*AuditItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &AuditItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for AuditItem.
+func (c *AuditItemClient) Update() *AuditItemUpdate {
+	mutation := newAuditItemMutation(c.config, OpUpdate)
+	return &AuditItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *AuditItemClient) UpdateOne(_m *AuditItem) *AuditItemUpdateOne {
+	mutation := newAuditItemMutation(c.config, OpUpdateOne, withAuditItem(_m))
+	return &AuditItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *AuditItemClient) UpdateOneID(id uint64) *AuditItemUpdateOne {
+	mutation := newAuditItemMutation(c.config, OpUpdateOne, withAuditItemID(id))
+	return &AuditItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for AuditItem.
+func (c *AuditItemClient) Delete() *AuditItemDelete {
+	mutation := newAuditItemMutation(c.config, OpDelete)
+	return &AuditItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *AuditItemClient) DeleteOne(_m *AuditItem) *AuditItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *AuditItemClient) DeleteOneID(id uint64) *AuditItemDeleteOne {
+	builder := c.Delete().Where(audititem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &AuditItemDeleteOne{builder}
+}
+
+// Query returns a query builder for AuditItem.
+func (c *AuditItemClient) Query() *AuditItemQuery {
+	return &AuditItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeAuditItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a AuditItem entity by its id.
+func (c *AuditItemClient) Get(ctx context.Context, id uint64) (*AuditItem, error) {
+	return c.Query().Where(audititem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *AuditItemClient) GetX(ctx context.Context, id uint64) *AuditItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *AuditItemClient) Hooks() []Hook {
+	hooks := c.hooks.AuditItem
+	return append(hooks[:len(hooks):len(hooks)], audititem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *AuditItemClient) Interceptors() []Interceptor {
+	inters := c.inters.AuditItem
+	return append(inters[:len(inters):len(inters)], audititem.Interceptors[:]...)
+}
+
+func (c *AuditItemClient) mutate(ctx context.Context, m *AuditItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&AuditItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&AuditItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&AuditItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&AuditItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown AuditItem mutation op: %q", m.Op())
+	}
+}
+
+// DataPermItemClient is a client for the DataPermItem schema.
+type DataPermItemClient struct {
+	config
+}
+
+// NewDataPermItemClient returns a client for the DataPermItem from the given config.
+func NewDataPermItemClient(c config) *DataPermItemClient {
+	return &DataPermItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `datapermitem.Hooks(f(g(h())))`.
+func (c *DataPermItemClient) Use(hooks ...Hook) {
+	c.hooks.DataPermItem = append(c.hooks.DataPermItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `datapermitem.Intercept(f(g(h())))`.
+func (c *DataPermItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.DataPermItem = append(c.inters.DataPermItem, interceptors...)
+}
+
+// Create returns a builder for creating a DataPermItem entity.
+func (c *DataPermItemClient) Create() *DataPermItemCreate {
+	mutation := newDataPermItemMutation(c.config, OpCreate)
+	return &DataPermItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of DataPermItem entities.
+func (c *DataPermItemClient) CreateBulk(builders ...*DataPermItemCreate) *DataPermItemCreateBulk {
+	return &DataPermItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *DataPermItemClient) MapCreateBulk(slice any, setFunc func(*DataPermItemCreate, int)) *DataPermItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &DataPermItemCreateBulk{err: fmt.Errorf("calling to DataPermItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*DataPermItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &DataPermItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for DataPermItem.
+func (c *DataPermItemClient) Update() *DataPermItemUpdate {
+	mutation := newDataPermItemMutation(c.config, OpUpdate)
+	return &DataPermItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *DataPermItemClient) UpdateOne(_m *DataPermItem) *DataPermItemUpdateOne {
+	mutation := newDataPermItemMutation(c.config, OpUpdateOne, withDataPermItem(_m))
+	return &DataPermItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *DataPermItemClient) UpdateOneID(id uint64) *DataPermItemUpdateOne {
+	mutation := newDataPermItemMutation(c.config, OpUpdateOne, withDataPermItemID(id))
+	return &DataPermItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for DataPermItem.
+func (c *DataPermItemClient) Delete() *DataPermItemDelete {
+	mutation := newDataPermItemMutation(c.config, OpDelete)
+	return &DataPermItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *DataPermItemClient) DeleteOne(_m *DataPermItem) *DataPermItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *DataPermItemClient) DeleteOneID(id uint64) *DataPermItemDeleteOne {
+	builder := c.Delete().Where(datapermitem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &DataPermItemDeleteOne{builder}
+}
+
+// Query returns a query builder for DataPermItem.
+func (c *DataPermItemClient) Query() *DataPermItemQuery {
+	return &DataPermItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeDataPermItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a DataPermItem entity by its id.
+func (c *DataPermItemClient) Get(ctx context.Context, id uint64) (*DataPermItem, error) {
+	return c.Query().Where(datapermitem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *DataPermItemClient) GetX(ctx context.Context, id uint64) *DataPermItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *DataPermItemClient) Hooks() []Hook {
+	hooks := c.hooks.DataPermItem
+	return append(hooks[:len(hooks):len(hooks)], datapermitem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *DataPermItemClient) Interceptors() []Interceptor {
+	inters := c.inters.DataPermItem
+	return append(inters[:len(inters):len(inters)], datapermitem.Interceptors[:]...)
+}
+
+func (c *DataPermItemClient) mutate(ctx context.Context, m *DataPermItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&DataPermItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&DataPermItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&DataPermItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&DataPermItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown DataPermItem mutation op: %q", m.Op())
+	}
+}
+
+// VersionItemClient is a client for the VersionItem schema.
+type VersionItemClient struct {
+	config
+}
+
+// NewVersionItemClient returns a client for the VersionItem from the given config.
+func NewVersionItemClient(c config) *VersionItemClient {
+	return &VersionItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `versionitem.Hooks(f(g(h())))`.
+func (c *VersionItemClient) Use(hooks ...Hook) {
+	c.hooks.VersionItem = append(c.hooks.VersionItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `versionitem.Intercept(f(g(h())))`.
+func (c *VersionItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.VersionItem = append(c.inters.VersionItem, interceptors...)
+}
+
+// Create returns a builder for creating a VersionItem entity.
+func (c *VersionItemClient) Create() *VersionItemCreate {
+	mutation := newVersionItemMutation(c.config, OpCreate)
+	return &VersionItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of VersionItem entities.
+func (c *VersionItemClient) CreateBulk(builders ...*VersionItemCreate) *VersionItemCreateBulk {
+	return &VersionItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *VersionItemClient) MapCreateBulk(slice any, setFunc func(*VersionItemCreate, int)) *VersionItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &VersionItemCreateBulk{err: fmt.Errorf("calling to VersionItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*VersionItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &VersionItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for VersionItem.
+func (c *VersionItemClient) Update() *VersionItemUpdate {
+	mutation := newVersionItemMutation(c.config, OpUpdate)
+	return &VersionItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *VersionItemClient) UpdateOne(_m *VersionItem) *VersionItemUpdateOne {
+	mutation := newVersionItemMutation(c.config, OpUpdateOne, withVersionItem(_m))
+	return &VersionItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *VersionItemClient) UpdateOneID(id uint64) *VersionItemUpdateOne {
+	mutation := newVersionItemMutation(c.config, OpUpdateOne, withVersionItemID(id))
+	return &VersionItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for VersionItem.
+func (c *VersionItemClient) Delete() *VersionItemDelete {
+	mutation := newVersionItemMutation(c.config, OpDelete)
+	return &VersionItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *VersionItemClient) DeleteOne(_m *VersionItem) *VersionItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *VersionItemClient) DeleteOneID(id uint64) *VersionItemDeleteOne {
+	builder := c.Delete().Where(versionitem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &VersionItemDeleteOne{builder}
+}
+
+// Query returns a query builder for VersionItem.
+func (c *VersionItemClient) Query() *VersionItemQuery {
+	return &VersionItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeVersionItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a VersionItem entity by its id.
+func (c *VersionItemClient) Get(ctx context.Context, id uint64) (*VersionItem, error) {
+	return c.Query().Where(versionitem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *VersionItemClient) GetX(ctx context.Context, id uint64) *VersionItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *VersionItemClient) Hooks() []Hook {
+	hooks := c.hooks.VersionItem
+	return append(hooks[:len(hooks):len(hooks)], versionitem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *VersionItemClient) Interceptors() []Interceptor {
+	return c.inters.VersionItem
+}
+
+func (c *VersionItemClient) mutate(ctx context.Context, m *VersionItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&VersionItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&VersionItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&VersionItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&VersionItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown VersionItem mutation op: %q", m.Op())
+	}
+}
+
+// UUIDAuditItemClient is a client for the UUIDAuditItem schema.
+type UUIDAuditItemClient struct {
+	config
+}
+
+// NewUUIDAuditItemClient returns a client for the UUIDAuditItem from the given config.
+func NewUUIDAuditItemClient(c config) *UUIDAuditItemClient {
+	return &UUIDAuditItemClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `uuidaudititem.Hooks(f(g(h())))`.
+func (c *UUIDAuditItemClient) Use(hooks ...Hook) {
+	c.hooks.UUIDAuditItem = append(c.hooks.UUIDAuditItem, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `uuidaudititem.Intercept(f(g(h())))`.
+func (c *UUIDAuditItemClient) Intercept(interceptors ...Interceptor) {
+	c.inters.UUIDAuditItem = append(c.inters.UUIDAuditItem, interceptors...)
+}
+
+// Create returns a builder for creating a UUIDAuditItem entity.
+func (c *UUIDAuditItemClient) Create() *UUIDAuditItemCreate {
+	mutation := newUUIDAuditItemMutation(c.config, OpCreate)
+	return &UUIDAuditItemCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of UUIDAuditItem entities.
+func (c *UUIDAuditItemClient) CreateBulk(builders ...*UUIDAuditItemCreate) *UUIDAuditItemCreateBulk {
+	return &UUIDAuditItemCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *UUIDAuditItemClient) MapCreateBulk(slice any, setFunc func(*UUIDAuditItemCreate, int)) *UUIDAuditItemCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &UUIDAuditItemCreateBulk{err: fmt.Errorf("calling to UUIDAuditItemClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*UUIDAuditItemCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &UUIDAuditItemCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for UUIDAuditItem.
+func (c *UUIDAuditItemClient) Update() *UUIDAuditItemUpdate {
+	mutation := newUUIDAuditItemMutation(c.config, OpUpdate)
+	return &UUIDAuditItemUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *UUIDAuditItemClient) UpdateOne(_m *UUIDAuditItem) *UUIDAuditItemUpdateOne {
+	mutation := newUUIDAuditItemMutation(c.config, OpUpdateOne, withUUIDAuditItem(_m))
+	return &UUIDAuditItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *UUIDAuditItemClient) UpdateOneID(id uint64) *UUIDAuditItemUpdateOne {
+	mutation := newUUIDAuditItemMutation(c.config, OpUpdateOne, withUUIDAuditItemID(id))
+	return &UUIDAuditItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for UUIDAuditItem.
+func (c *UUIDAuditItemClient) Delete() *UUIDAuditItemDelete {
+	mutation := newUUIDAuditItemMutation(c.config, OpDelete)
+	return &UUIDAuditItemDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *UUIDAuditItemClient) DeleteOne(_m *UUIDAuditItem) *UUIDAuditItemDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *UUIDAuditItemClient) DeleteOneID(id uint64) *UUIDAuditItemDeleteOne {
+	builder := c.Delete().Where(uuidaudititem.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &UUIDAuditItemDeleteOne{builder}
+}
+
+// Query returns a query builder for UUIDAuditItem.
+func (c *UUIDAuditItemClient) Query() *UUIDAuditItemQuery {
+	return &UUIDAuditItemQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeUUIDAuditItem},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a UUIDAuditItem entity by its id.
+func (c *UUIDAuditItemClient) Get(ctx context.Context, id uint64) (*UUIDAuditItem, error) {
+	return c.Query().Where(uuidaudititem.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *UUIDAuditItemClient) GetX(ctx context.Context, id uint64) *UUIDAuditItem {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *UUIDAuditItemClient) Hooks() []Hook {
+	hooks := c.hooks.UUIDAuditItem
+	return append(hooks[:len(hooks):len(hooks)], uuidaudititem.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *UUIDAuditItemClient) Interceptors() []Interceptor {
+	return c.inters.UUIDAuditItem
+}
+
+func (c *UUIDAuditItemClient) mutate(ctx context.Context, m *UUIDAuditItemMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&UUIDAuditItemCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&UUIDAuditItemUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&UUIDAuditItemUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&UUIDAuditItemDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown UUIDAuditItem mutation op: %q", m.Op())
+	}
+}
+
+// hooks and interceptors per client, for fast access.
+type (
+	hooks struct {
+		TenantItem, DepartmentItem, HistoryItem, SoftDeleteItem, AuditItem,
+		DataPermItem, VersionItem, UUIDAuditItem []ent.Hook
+	}
+	inters struct {
+		TenantItem, DepartmentItem, HistoryItem, SoftDeleteItem, AuditItem,
+		DataPermItem, VersionItem, UUIDAuditItem []ent.Interceptor
+	}
+)
+
+// ExecContext allows calling the underlying ExecContext method of the driver if it is supported by it.
+// See, database/sql#DB.ExecContext for more information.
+func (c *config) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	ex, ok := c.driver.(interface {
+		ExecContext(context.Context, string, ...any) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext allows calling the underlying QueryContext method of the driver if it is supported by it.
+// See, database/sql#DB.QueryContext for more information.
+func (c *config) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	q, ok := c.driver.(interface {
+		QueryContext(context.Context, string, ...any) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
@@ -0,0 +1,315 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/datapermitem"
+)
+
+// DataPermItemCreate is the builder for creating a DataPermItem entity.
+type DataPermItemCreate struct {
+	config
+	mutation *DataPermItemMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *DataPermItemCreate) SetCreatedAt(v time.Time) *DataPermItemCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *DataPermItemCreate) SetNillableCreatedAt(v *time.Time) *DataPermItemCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *DataPermItemCreate) SetUpdatedAt(v time.Time) *DataPermItemCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *DataPermItemCreate) SetNillableUpdatedAt(v *time.Time) *DataPermItemCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_c *DataPermItemCreate) SetCreatedBy(v uint64) *DataPermItemCreate {
+	_c.mutation.SetCreatedBy(v)
+	return _c
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_c *DataPermItemCreate) SetNillableCreatedBy(v *uint64) *DataPermItemCreate {
+	if v != nil {
+		_c.SetCreatedBy(*v)
+	}
+	return _c
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_c *DataPermItemCreate) SetUpdatedBy(v uint64) *DataPermItemCreate {
+	_c.mutation.SetUpdatedBy(v)
+	return _c
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_c *DataPermItemCreate) SetNillableUpdatedBy(v *uint64) *DataPermItemCreate {
+	if v != nil {
+		_c.SetUpdatedBy(*v)
+	}
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *DataPermItemCreate) SetName(v string) *DataPermItemCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetDepartmentID sets the "department_id" field.
+func (_c *DataPermItemCreate) SetDepartmentID(v uint64) *DataPermItemCreate {
+	_c.mutation.SetDepartmentID(v)
+	return _c
+}
+
+// SetNillableDepartmentID sets the "department_id" field if the given value is not nil.
+func (_c *DataPermItemCreate) SetNillableDepartmentID(v *uint64) *DataPermItemCreate {
+	if v != nil {
+		_c.SetDepartmentID(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *DataPermItemCreate) SetID(v uint64) *DataPermItemCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the DataPermItemMutation object of the builder.
+func (_c *DataPermItemCreate) Mutation() *DataPermItemMutation {
+	return _c.mutation
+}
+
+// Save creates the DataPermItem in the database.
+func (_c *DataPermItemCreate) Save(ctx context.Context) (*DataPermItem, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *DataPermItemCreate) SaveX(ctx context.Context) *DataPermItem {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DataPermItemCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DataPermItemCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *DataPermItemCreate) defaults() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		if datapermitem.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized datapermitem.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := datapermitem.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		if datapermitem.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized datapermitem.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := datapermitem.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *DataPermItemCreate) check() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "DataPermItem.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "DataPermItem.updated_at"`)}
+	}
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "DataPermItem.name"`)}
+	}
+	return nil
+}
+
+func (_c *DataPermItemCreate) sqlSave(ctx context.Context) (*DataPermItem, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = uint64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *DataPermItemCreate) createSpec() (*DataPermItem, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DataPermItem{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(datapermitem.Table, sqlgraph.NewFieldSpec(datapermitem.FieldID, field.TypeUint64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(datapermitem.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(datapermitem.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.CreatedBy(); ok {
+		_spec.SetField(datapermitem.FieldCreatedBy, field.TypeUint64, value)
+		_node.CreatedBy = value
+	}
+	if value, ok := _c.mutation.UpdatedBy(); ok {
+		_spec.SetField(datapermitem.FieldUpdatedBy, field.TypeUint64, value)
+		_node.UpdatedBy = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(datapermitem.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.DepartmentID(); ok {
+		_spec.SetField(datapermitem.FieldDepartmentID, field.TypeUint64, value)
+		_node.DepartmentID = value
+	}
+	return _node, _spec
+}
+
+// DataPermItemCreateBulk is the builder for creating many DataPermItem entities in bulk.
+type DataPermItemCreateBulk struct {
+	config
+	err      error
+	builders []*DataPermItemCreate
+}
+
+// Save creates the DataPermItem entities in the database.
+func (_c *DataPermItemCreateBulk) Save(ctx context.Context) ([]*DataPermItem, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*DataPermItem, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DataPermItemMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = uint64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *DataPermItemCreateBulk) SaveX(ctx context.Context) []*DataPermItem {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DataPermItemCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DataPermItemCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
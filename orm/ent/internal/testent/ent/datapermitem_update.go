@@ -0,0 +1,408 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/datapermitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/predicate"
+)
+
+// DataPermItemUpdate is the builder for updating DataPermItem entities.
+type DataPermItemUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DataPermItemMutation
+}
+
+// Where appends a list predicates to the DataPermItemUpdate builder.
+func (_u *DataPermItemUpdate) Where(ps ...predicate.DataPermItem) *DataPermItemUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *DataPermItemUpdate) SetUpdatedAt(v time.Time) *DataPermItemUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *DataPermItemUpdate) SetUpdatedBy(v uint64) *DataPermItemUpdate {
+	_u.mutation.ResetUpdatedBy()
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *DataPermItemUpdate) SetNillableUpdatedBy(v *uint64) *DataPermItemUpdate {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// AddUpdatedBy adds value to the "updated_by" field.
+func (_u *DataPermItemUpdate) AddUpdatedBy(v int64) *DataPermItemUpdate {
+	_u.mutation.AddUpdatedBy(v)
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *DataPermItemUpdate) ClearUpdatedBy() *DataPermItemUpdate {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *DataPermItemUpdate) SetName(v string) *DataPermItemUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *DataPermItemUpdate) SetNillableName(v *string) *DataPermItemUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDepartmentID sets the "department_id" field.
+func (_u *DataPermItemUpdate) SetDepartmentID(v uint64) *DataPermItemUpdate {
+	_u.mutation.ResetDepartmentID()
+	_u.mutation.SetDepartmentID(v)
+	return _u
+}
+
+// SetNillableDepartmentID sets the "department_id" field if the given value is not nil.
+func (_u *DataPermItemUpdate) SetNillableDepartmentID(v *uint64) *DataPermItemUpdate {
+	if v != nil {
+		_u.SetDepartmentID(*v)
+	}
+	return _u
+}
+
+// AddDepartmentID adds value to the "department_id" field.
+func (_u *DataPermItemUpdate) AddDepartmentID(v int64) *DataPermItemUpdate {
+	_u.mutation.AddDepartmentID(v)
+	return _u
+}
+
+// ClearDepartmentID clears the value of the "department_id" field.
+func (_u *DataPermItemUpdate) ClearDepartmentID() *DataPermItemUpdate {
+	_u.mutation.ClearDepartmentID()
+	return _u
+}
+
+// Mutation returns the DataPermItemMutation object of the builder.
+func (_u *DataPermItemUpdate) Mutation() *DataPermItemMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DataPermItemUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DataPermItemUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DataPermItemUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DataPermItemUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DataPermItemUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if datapermitem.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized datapermitem.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := datapermitem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (_u *DataPermItemUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(datapermitem.Table, datapermitem.Columns, sqlgraph.NewFieldSpec(datapermitem.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(datapermitem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(datapermitem.FieldCreatedBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(datapermitem.FieldUpdatedBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(datapermitem.FieldUpdatedBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(datapermitem.FieldUpdatedBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(datapermitem.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.DepartmentID(); ok {
+		_spec.SetField(datapermitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedDepartmentID(); ok {
+		_spec.AddField(datapermitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if _u.mutation.DepartmentIDCleared() {
+		_spec.ClearField(datapermitem.FieldDepartmentID, field.TypeUint64)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{datapermitem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DataPermItemUpdateOne is the builder for updating a single DataPermItem entity.
+type DataPermItemUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DataPermItemMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *DataPermItemUpdateOne) SetUpdatedAt(v time.Time) *DataPermItemUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *DataPermItemUpdateOne) SetUpdatedBy(v uint64) *DataPermItemUpdateOne {
+	_u.mutation.ResetUpdatedBy()
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *DataPermItemUpdateOne) SetNillableUpdatedBy(v *uint64) *DataPermItemUpdateOne {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// AddUpdatedBy adds value to the "updated_by" field.
+func (_u *DataPermItemUpdateOne) AddUpdatedBy(v int64) *DataPermItemUpdateOne {
+	_u.mutation.AddUpdatedBy(v)
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *DataPermItemUpdateOne) ClearUpdatedBy() *DataPermItemUpdateOne {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *DataPermItemUpdateOne) SetName(v string) *DataPermItemUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *DataPermItemUpdateOne) SetNillableName(v *string) *DataPermItemUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDepartmentID sets the "department_id" field.
+func (_u *DataPermItemUpdateOne) SetDepartmentID(v uint64) *DataPermItemUpdateOne {
+	_u.mutation.ResetDepartmentID()
+	_u.mutation.SetDepartmentID(v)
+	return _u
+}
+
+// SetNillableDepartmentID sets the "department_id" field if the given value is not nil.
+func (_u *DataPermItemUpdateOne) SetNillableDepartmentID(v *uint64) *DataPermItemUpdateOne {
+	if v != nil {
+		_u.SetDepartmentID(*v)
+	}
+	return _u
+}
+
+// AddDepartmentID adds value to the "department_id" field.
+func (_u *DataPermItemUpdateOne) AddDepartmentID(v int64) *DataPermItemUpdateOne {
+	_u.mutation.AddDepartmentID(v)
+	return _u
+}
+
+// ClearDepartmentID clears the value of the "department_id" field.
+func (_u *DataPermItemUpdateOne) ClearDepartmentID() *DataPermItemUpdateOne {
+	_u.mutation.ClearDepartmentID()
+	return _u
+}
+
+// Mutation returns the DataPermItemMutation object of the builder.
+func (_u *DataPermItemUpdateOne) Mutation() *DataPermItemMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the DataPermItemUpdate builder.
+func (_u *DataPermItemUpdateOne) Where(ps ...predicate.DataPermItem) *DataPermItemUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DataPermItemUpdateOne) Select(field string, fields ...string) *DataPermItemUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated DataPermItem entity.
+func (_u *DataPermItemUpdateOne) Save(ctx context.Context) (*DataPermItem, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DataPermItemUpdateOne) SaveX(ctx context.Context) *DataPermItem {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DataPermItemUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DataPermItemUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DataPermItemUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if datapermitem.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized datapermitem.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := datapermitem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (_u *DataPermItemUpdateOne) sqlSave(ctx context.Context) (_node *DataPermItem, err error) {
+	_spec := sqlgraph.NewUpdateSpec(datapermitem.Table, datapermitem.Columns, sqlgraph.NewFieldSpec(datapermitem.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DataPermItem.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, datapermitem.FieldID)
+		for _, f := range fields {
+			if !datapermitem.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != datapermitem.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(datapermitem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(datapermitem.FieldCreatedBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(datapermitem.FieldUpdatedBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(datapermitem.FieldUpdatedBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(datapermitem.FieldUpdatedBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(datapermitem.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.DepartmentID(); ok {
+		_spec.SetField(datapermitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedDepartmentID(); ok {
+		_spec.AddField(datapermitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if _u.mutation.DepartmentIDCleared() {
+		_spec.ClearField(datapermitem.FieldDepartmentID, field.TypeUint64)
+	}
+	_node = &DataPermItem{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{datapermitem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
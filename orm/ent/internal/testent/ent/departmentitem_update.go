@@ -0,0 +1,318 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/departmentitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/predicate"
+)
+
+// DepartmentItemUpdate is the builder for updating DepartmentItem entities.
+type DepartmentItemUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DepartmentItemMutation
+}
+
+// Where appends a list predicates to the DepartmentItemUpdate builder.
+func (_u *DepartmentItemUpdate) Where(ps ...predicate.DepartmentItem) *DepartmentItemUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *DepartmentItemUpdate) SetUpdatedAt(v time.Time) *DepartmentItemUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDepartmentID sets the "department_id" field.
+func (_u *DepartmentItemUpdate) SetDepartmentID(v uint64) *DepartmentItemUpdate {
+	_u.mutation.ResetDepartmentID()
+	_u.mutation.SetDepartmentID(v)
+	return _u
+}
+
+// SetNillableDepartmentID sets the "department_id" field if the given value is not nil.
+func (_u *DepartmentItemUpdate) SetNillableDepartmentID(v *uint64) *DepartmentItemUpdate {
+	if v != nil {
+		_u.SetDepartmentID(*v)
+	}
+	return _u
+}
+
+// AddDepartmentID adds value to the "department_id" field.
+func (_u *DepartmentItemUpdate) AddDepartmentID(v int64) *DepartmentItemUpdate {
+	_u.mutation.AddDepartmentID(v)
+	return _u
+}
+
+// ClearDepartmentID clears the value of the "department_id" field.
+func (_u *DepartmentItemUpdate) ClearDepartmentID() *DepartmentItemUpdate {
+	_u.mutation.ClearDepartmentID()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *DepartmentItemUpdate) SetName(v string) *DepartmentItemUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *DepartmentItemUpdate) SetNillableName(v *string) *DepartmentItemUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// Mutation returns the DepartmentItemMutation object of the builder.
+func (_u *DepartmentItemUpdate) Mutation() *DepartmentItemMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DepartmentItemUpdate) Save(ctx context.Context) (int, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DepartmentItemUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DepartmentItemUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DepartmentItemUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DepartmentItemUpdate) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := departmentitem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *DepartmentItemUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(departmentitem.Table, departmentitem.Columns, sqlgraph.NewFieldSpec(departmentitem.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(departmentitem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DepartmentID(); ok {
+		_spec.SetField(departmentitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedDepartmentID(); ok {
+		_spec.AddField(departmentitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if _u.mutation.DepartmentIDCleared() {
+		_spec.ClearField(departmentitem.FieldDepartmentID, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(departmentitem.FieldName, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{departmentitem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DepartmentItemUpdateOne is the builder for updating a single DepartmentItem entity.
+type DepartmentItemUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DepartmentItemMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *DepartmentItemUpdateOne) SetUpdatedAt(v time.Time) *DepartmentItemUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDepartmentID sets the "department_id" field.
+func (_u *DepartmentItemUpdateOne) SetDepartmentID(v uint64) *DepartmentItemUpdateOne {
+	_u.mutation.ResetDepartmentID()
+	_u.mutation.SetDepartmentID(v)
+	return _u
+}
+
+// SetNillableDepartmentID sets the "department_id" field if the given value is not nil.
+func (_u *DepartmentItemUpdateOne) SetNillableDepartmentID(v *uint64) *DepartmentItemUpdateOne {
+	if v != nil {
+		_u.SetDepartmentID(*v)
+	}
+	return _u
+}
+
+// AddDepartmentID adds value to the "department_id" field.
+func (_u *DepartmentItemUpdateOne) AddDepartmentID(v int64) *DepartmentItemUpdateOne {
+	_u.mutation.AddDepartmentID(v)
+	return _u
+}
+
+// ClearDepartmentID clears the value of the "department_id" field.
+func (_u *DepartmentItemUpdateOne) ClearDepartmentID() *DepartmentItemUpdateOne {
+	_u.mutation.ClearDepartmentID()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *DepartmentItemUpdateOne) SetName(v string) *DepartmentItemUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *DepartmentItemUpdateOne) SetNillableName(v *string) *DepartmentItemUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// Mutation returns the DepartmentItemMutation object of the builder.
+func (_u *DepartmentItemUpdateOne) Mutation() *DepartmentItemMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the DepartmentItemUpdate builder.
+func (_u *DepartmentItemUpdateOne) Where(ps ...predicate.DepartmentItem) *DepartmentItemUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DepartmentItemUpdateOne) Select(field string, fields ...string) *DepartmentItemUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated DepartmentItem entity.
+func (_u *DepartmentItemUpdateOne) Save(ctx context.Context) (*DepartmentItem, error) {
+	_u.defaults()
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DepartmentItemUpdateOne) SaveX(ctx context.Context) *DepartmentItem {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DepartmentItemUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DepartmentItemUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DepartmentItemUpdateOne) defaults() {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		v := departmentitem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (_u *DepartmentItemUpdateOne) sqlSave(ctx context.Context) (_node *DepartmentItem, err error) {
+	_spec := sqlgraph.NewUpdateSpec(departmentitem.Table, departmentitem.Columns, sqlgraph.NewFieldSpec(departmentitem.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DepartmentItem.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, departmentitem.FieldID)
+		for _, f := range fields {
+			if !departmentitem.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != departmentitem.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(departmentitem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DepartmentID(); ok {
+		_spec.SetField(departmentitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedDepartmentID(); ok {
+		_spec.AddField(departmentitem.FieldDepartmentID, field.TypeUint64, value)
+	}
+	if _u.mutation.DepartmentIDCleared() {
+		_spec.ClearField(departmentitem.FieldDepartmentID, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(departmentitem.FieldName, field.TypeString, value)
+	}
+	_node = &DepartmentItem{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{departmentitem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
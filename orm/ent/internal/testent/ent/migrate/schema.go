@@ -0,0 +1,142 @@
+// Code generated by ent, DO NOT EDIT.
+
+package migrate
+
+import (
+	"entgo.io/ent/dialect/sql/schema"
+	"entgo.io/ent/schema/field"
+)
+
+var (
+	// TenantItemsColumns holds the columns for the "tenant_items" table.
+	TenantItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "tenant_id", Type: field.TypeUint64, Default: 1},
+		{Name: "name", Type: field.TypeString},
+	}
+	// TenantItemsTable holds the schema information for the "tenant_items" table.
+	TenantItemsTable = &schema.Table{
+		Name:       "tenant_items",
+		Columns:    TenantItemsColumns,
+		PrimaryKey: []*schema.Column{TenantItemsColumns[0]},
+	}
+	// DepartmentItemsColumns holds the columns for the "department_items" table.
+	DepartmentItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "department_id", Type: field.TypeUint64, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+	}
+	// DepartmentItemsTable holds the schema information for the "department_items" table.
+	DepartmentItemsTable = &schema.Table{
+		Name:       "department_items",
+		Columns:    DepartmentItemsColumns,
+		PrimaryKey: []*schema.Column{DepartmentItemsColumns[0]},
+	}
+	// HistoryItemsColumns holds the columns for the "history_items" table.
+	HistoryItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "name", Type: field.TypeString},
+	}
+	// HistoryItemsTable holds the schema information for the "history_items" table.
+	HistoryItemsTable = &schema.Table{
+		Name:       "history_items",
+		Columns:    HistoryItemsColumns,
+		PrimaryKey: []*schema.Column{HistoryItemsColumns[0]},
+	}
+	// SoftDeleteItemsColumns holds the columns for the "soft_delete_items" table.
+	SoftDeleteItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+	}
+	// SoftDeleteItemsTable holds the schema information for the "soft_delete_items" table.
+	SoftDeleteItemsTable = &schema.Table{
+		Name:       "soft_delete_items",
+		Columns:    SoftDeleteItemsColumns,
+		PrimaryKey: []*schema.Column{SoftDeleteItemsColumns[0]},
+	}
+	// AuditItemsColumns holds the columns for the "audit_items" table.
+	AuditItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "created_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "updated_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "version", Type: field.TypeInt, Default: 0},
+		{Name: "deleted_at", Type: field.TypeTime, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+	}
+	// AuditItemsTable holds the schema information for the "audit_items" table.
+	AuditItemsTable = &schema.Table{
+		Name:       "audit_items",
+		Columns:    AuditItemsColumns,
+		PrimaryKey: []*schema.Column{AuditItemsColumns[0]},
+	}
+	// DataPermItemsColumns holds the columns for the "data_perm_items" table.
+	DataPermItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "created_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "updated_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "department_id", Type: field.TypeUint64, Nullable: true},
+	}
+	// DataPermItemsTable holds the schema information for the "data_perm_items" table.
+	DataPermItemsTable = &schema.Table{
+		Name:       "data_perm_items",
+		Columns:    DataPermItemsColumns,
+		PrimaryKey: []*schema.Column{DataPermItemsColumns[0]},
+	}
+	// VersionItemsColumns holds the columns for the "version_items" table.
+	VersionItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "version", Type: field.TypeInt, Default: 0},
+		{Name: "name", Type: field.TypeString},
+	}
+	// VersionItemsTable holds the schema information for the "version_items" table.
+	VersionItemsTable = &schema.Table{
+		Name:       "version_items",
+		Columns:    VersionItemsColumns,
+		PrimaryKey: []*schema.Column{VersionItemsColumns[0]},
+	}
+	// UUIDAuditItemsColumns holds the columns for the "uuid_audit_items" table.
+	UUIDAuditItemsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "created_by", Type: field.TypeUUID, Nullable: true},
+		{Name: "updated_by", Type: field.TypeUUID, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+	}
+	// UUIDAuditItemsTable holds the schema information for the "uuid_audit_items" table.
+	UUIDAuditItemsTable = &schema.Table{
+		Name:       "uuid_audit_items",
+		Columns:    UUIDAuditItemsColumns,
+		PrimaryKey: []*schema.Column{UUIDAuditItemsColumns[0]},
+	}
+	// Tables holds all the tables in the schema.
+	Tables = []*schema.Table{
+		TenantItemsTable,
+		DepartmentItemsTable,
+		HistoryItemsTable,
+		SoftDeleteItemsTable,
+		AuditItemsTable,
+		DataPermItemsTable,
+		VersionItemsTable,
+		UUIDAuditItemsTable,
+	}
+)
+
+func init() {
+}
@@ -0,0 +1,31 @@
+// Code generated by ent, DO NOT EDIT.
+
+package predicate
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+// TenantItem is the predicate function for tenantitem builders.
+type TenantItem func(*sql.Selector)
+
+// DepartmentItem is the predicate function for departmentitem builders.
+type DepartmentItem func(*sql.Selector)
+
+// HistoryItem is the predicate function for historyitem builders.
+type HistoryItem func(*sql.Selector)
+
+// SoftDeleteItem is the predicate function for softdeleteitem builders.
+type SoftDeleteItem func(*sql.Selector)
+
+// AuditItem is the predicate function for audititem builders.
+type AuditItem func(*sql.Selector)
+
+// DataPermItem is the predicate function for datapermitem builders.
+type DataPermItem func(*sql.Selector)
+
+// VersionItem is the predicate function for versionitem builders.
+type VersionItem func(*sql.Selector)
+
+// UUIDAuditItem is the predicate function for uuidaudititem builders.
+type UUIDAuditItem func(*sql.Selector)
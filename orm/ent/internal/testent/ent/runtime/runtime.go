@@ -0,0 +1,202 @@
+// Code generated by ent, DO NOT EDIT.
+
+package runtime
+
+import (
+	"time"
+
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/audititem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/datapermitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/departmentitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/historyitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/softdeleteitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/tenantitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/uuidaudititem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/versionitem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/schema"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	tenantitemMixin := schema.TenantItem{}.Mixin()
+	tenantitemMixinHooks1 := tenantitemMixin[1].Hooks()
+	tenantitem.Hooks[0] = tenantitemMixinHooks1[0]
+	tenantitem.Hooks[1] = tenantitemMixinHooks1[1]
+	tenantitemMixinInters1 := tenantitemMixin[1].Interceptors()
+	tenantitem.Interceptors[0] = tenantitemMixinInters1[0]
+	tenantitemMixinFields0 := tenantitemMixin[0].Fields()
+	_ = tenantitemMixinFields0
+	tenantitemMixinFields1 := tenantitemMixin[1].Fields()
+	_ = tenantitemMixinFields1
+	tenantitemFields := schema.TenantItem{}.Fields()
+	_ = tenantitemFields
+	// tenantitemDescCreatedAt is the schema descriptor for created_at field.
+	tenantitemDescCreatedAt := tenantitemMixinFields0[1].Descriptor()
+	// tenantitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	tenantitem.DefaultCreatedAt = tenantitemDescCreatedAt.Default.(func() time.Time)
+	// tenantitemDescUpdatedAt is the schema descriptor for updated_at field.
+	tenantitemDescUpdatedAt := tenantitemMixinFields0[2].Descriptor()
+	// tenantitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	tenantitem.DefaultUpdatedAt = tenantitemDescUpdatedAt.Default.(func() time.Time)
+	// tenantitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	tenantitem.UpdateDefaultUpdatedAt = tenantitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// tenantitemDescTenantID is the schema descriptor for tenant_id field.
+	tenantitemDescTenantID := tenantitemMixinFields1[0].Descriptor()
+	// tenantitem.DefaultTenantID holds the default value on creation for the tenant_id field.
+	tenantitem.DefaultTenantID = tenantitemDescTenantID.Default.(uint64)
+	departmentitemMixin := schema.DepartmentItem{}.Mixin()
+	departmentitemMixinInters1 := departmentitemMixin[1].Interceptors()
+	departmentitem.Interceptors[0] = departmentitemMixinInters1[0]
+	departmentitemMixinFields0 := departmentitemMixin[0].Fields()
+	_ = departmentitemMixinFields0
+	departmentitemFields := schema.DepartmentItem{}.Fields()
+	_ = departmentitemFields
+	// departmentitemDescCreatedAt is the schema descriptor for created_at field.
+	departmentitemDescCreatedAt := departmentitemMixinFields0[1].Descriptor()
+	// departmentitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	departmentitem.DefaultCreatedAt = departmentitemDescCreatedAt.Default.(func() time.Time)
+	// departmentitemDescUpdatedAt is the schema descriptor for updated_at field.
+	departmentitemDescUpdatedAt := departmentitemMixinFields0[2].Descriptor()
+	// departmentitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	departmentitem.DefaultUpdatedAt = departmentitemDescUpdatedAt.Default.(func() time.Time)
+	// departmentitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	departmentitem.UpdateDefaultUpdatedAt = departmentitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	historyitemMixin := schema.HistoryItem{}.Mixin()
+	historyitemMixinHooks1 := historyitemMixin[1].Hooks()
+	historyitem.Hooks[0] = historyitemMixinHooks1[0]
+	historyitemMixinFields0 := historyitemMixin[0].Fields()
+	_ = historyitemMixinFields0
+	historyitemFields := schema.HistoryItem{}.Fields()
+	_ = historyitemFields
+	// historyitemDescCreatedAt is the schema descriptor for created_at field.
+	historyitemDescCreatedAt := historyitemMixinFields0[1].Descriptor()
+	// historyitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	historyitem.DefaultCreatedAt = historyitemDescCreatedAt.Default.(func() time.Time)
+	// historyitemDescUpdatedAt is the schema descriptor for updated_at field.
+	historyitemDescUpdatedAt := historyitemMixinFields0[2].Descriptor()
+	// historyitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	historyitem.DefaultUpdatedAt = historyitemDescUpdatedAt.Default.(func() time.Time)
+	// historyitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	historyitem.UpdateDefaultUpdatedAt = historyitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	softdeleteitemMixin := schema.SoftDeleteItem{}.Mixin()
+	softdeleteitemMixinHooks1 := softdeleteitemMixin[1].Hooks()
+	softdeleteitem.Hooks[0] = softdeleteitemMixinHooks1[0]
+	softdeleteitem.Hooks[1] = softdeleteitemMixinHooks1[1]
+	softdeleteitemMixinInters1 := softdeleteitemMixin[1].Interceptors()
+	softdeleteitem.Interceptors[0] = softdeleteitemMixinInters1[0]
+	softdeleteitemMixinFields0 := softdeleteitemMixin[0].Fields()
+	_ = softdeleteitemMixinFields0
+	softdeleteitemFields := schema.SoftDeleteItem{}.Fields()
+	_ = softdeleteitemFields
+	// softdeleteitemDescCreatedAt is the schema descriptor for created_at field.
+	softdeleteitemDescCreatedAt := softdeleteitemMixinFields0[1].Descriptor()
+	// softdeleteitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	softdeleteitem.DefaultCreatedAt = softdeleteitemDescCreatedAt.Default.(func() time.Time)
+	// softdeleteitemDescUpdatedAt is the schema descriptor for updated_at field.
+	softdeleteitemDescUpdatedAt := softdeleteitemMixinFields0[2].Descriptor()
+	// softdeleteitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	softdeleteitem.DefaultUpdatedAt = softdeleteitemDescUpdatedAt.Default.(func() time.Time)
+	// softdeleteitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	softdeleteitem.UpdateDefaultUpdatedAt = softdeleteitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	audititemMixin := schema.AuditItem{}.Mixin()
+	audititemMixinHooks1 := audititemMixin[1].Hooks()
+	audititemMixinHooks2 := audititemMixin[2].Hooks()
+	audititemMixinHooks3 := audititemMixin[3].Hooks()
+	audititemMixinHooks4 := audititemMixin[4].Hooks()
+	audititem.Hooks[0] = audititemMixinHooks1[0]
+	audititem.Hooks[1] = audititemMixinHooks1[1]
+	audititem.Hooks[2] = audititemMixinHooks2[0]
+	audititem.Hooks[3] = audititemMixinHooks3[0]
+	audititem.Hooks[4] = audititemMixinHooks4[0]
+	audititem.Hooks[5] = audititemMixinHooks4[1]
+	audititemMixinInters4 := audititemMixin[4].Interceptors()
+	audititem.Interceptors[0] = audititemMixinInters4[0]
+	audititemMixinFields0 := audititemMixin[0].Fields()
+	_ = audititemMixinFields0
+	audititemMixinFields2 := audititemMixin[2].Fields()
+	_ = audititemMixinFields2
+	audititemFields := schema.AuditItem{}.Fields()
+	_ = audititemFields
+	// audititemDescCreatedAt is the schema descriptor for created_at field.
+	audititemDescCreatedAt := audititemMixinFields0[1].Descriptor()
+	// audititem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	audititem.DefaultCreatedAt = audititemDescCreatedAt.Default.(func() time.Time)
+	// audititemDescUpdatedAt is the schema descriptor for updated_at field.
+	audititemDescUpdatedAt := audititemMixinFields0[2].Descriptor()
+	// audititem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	audititem.DefaultUpdatedAt = audititemDescUpdatedAt.Default.(func() time.Time)
+	// audititem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	audititem.UpdateDefaultUpdatedAt = audititemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// audititemDescVersion is the schema descriptor for version field.
+	audititemDescVersion := audititemMixinFields2[0].Descriptor()
+	// audititem.DefaultVersion holds the default value on creation for the version field.
+	audititem.DefaultVersion = audititemDescVersion.Default.(int)
+	datapermitemMixin := schema.DataPermItem{}.Mixin()
+	datapermitemMixinHooks1 := datapermitemMixin[1].Hooks()
+	datapermitem.Hooks[0] = datapermitemMixinHooks1[0]
+	datapermitem.Hooks[1] = datapermitemMixinHooks1[1]
+	datapermitemMixinInters2 := datapermitemMixin[2].Interceptors()
+	datapermitem.Interceptors[0] = datapermitemMixinInters2[0]
+	datapermitemMixinFields0 := datapermitemMixin[0].Fields()
+	_ = datapermitemMixinFields0
+	datapermitemFields := schema.DataPermItem{}.Fields()
+	_ = datapermitemFields
+	// datapermitemDescCreatedAt is the schema descriptor for created_at field.
+	datapermitemDescCreatedAt := datapermitemMixinFields0[1].Descriptor()
+	// datapermitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	datapermitem.DefaultCreatedAt = datapermitemDescCreatedAt.Default.(func() time.Time)
+	// datapermitemDescUpdatedAt is the schema descriptor for updated_at field.
+	datapermitemDescUpdatedAt := datapermitemMixinFields0[2].Descriptor()
+	// datapermitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	datapermitem.DefaultUpdatedAt = datapermitemDescUpdatedAt.Default.(func() time.Time)
+	// datapermitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	datapermitem.UpdateDefaultUpdatedAt = datapermitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	versionitemMixin := schema.VersionItem{}.Mixin()
+	versionitemMixinHooks1 := versionitemMixin[1].Hooks()
+	versionitem.Hooks[0] = versionitemMixinHooks1[0]
+	versionitemMixinFields0 := versionitemMixin[0].Fields()
+	_ = versionitemMixinFields0
+	versionitemMixinFields1 := versionitemMixin[1].Fields()
+	_ = versionitemMixinFields1
+	versionitemFields := schema.VersionItem{}.Fields()
+	_ = versionitemFields
+	// versionitemDescCreatedAt is the schema descriptor for created_at field.
+	versionitemDescCreatedAt := versionitemMixinFields0[1].Descriptor()
+	// versionitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	versionitem.DefaultCreatedAt = versionitemDescCreatedAt.Default.(func() time.Time)
+	// versionitemDescUpdatedAt is the schema descriptor for updated_at field.
+	versionitemDescUpdatedAt := versionitemMixinFields0[2].Descriptor()
+	// versionitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	versionitem.DefaultUpdatedAt = versionitemDescUpdatedAt.Default.(func() time.Time)
+	// versionitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	versionitem.UpdateDefaultUpdatedAt = versionitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// versionitemDescVersion is the schema descriptor for version field.
+	versionitemDescVersion := versionitemMixinFields1[0].Descriptor()
+	// versionitem.DefaultVersion holds the default value on creation for the version field.
+	versionitem.DefaultVersion = versionitemDescVersion.Default.(int)
+	uuidaudititemMixin := schema.UUIDAuditItem{}.Mixin()
+	uuidaudititemMixinHooks1 := uuidaudititemMixin[1].Hooks()
+	uuidaudititem.Hooks[0] = uuidaudititemMixinHooks1[0]
+	uuidaudititem.Hooks[1] = uuidaudititemMixinHooks1[1]
+	uuidaudititemMixinFields0 := uuidaudititemMixin[0].Fields()
+	_ = uuidaudititemMixinFields0
+	uuidaudititemFields := schema.UUIDAuditItem{}.Fields()
+	_ = uuidaudititemFields
+	// uuidaudititemDescCreatedAt is the schema descriptor for created_at field.
+	uuidaudititemDescCreatedAt := uuidaudititemMixinFields0[1].Descriptor()
+	// uuidaudititem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	uuidaudititem.DefaultCreatedAt = uuidaudititemDescCreatedAt.Default.(func() time.Time)
+	// uuidaudititemDescUpdatedAt is the schema descriptor for updated_at field.
+	uuidaudititemDescUpdatedAt := uuidaudititemMixinFields0[2].Descriptor()
+	// uuidaudititem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	uuidaudititem.DefaultUpdatedAt = uuidaudititemDescUpdatedAt.Default.(func() time.Time)
+	// uuidaudititem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	uuidaudititem.UpdateDefaultUpdatedAt = uuidaudititemDescUpdatedAt.UpdateDefault.(func() time.Time)
+}
+
+const (
+	Version = "v0.14.5"                                         // Version of ent codegen.
+	Sum     = "h1:Rj2WOYJtCkWyFo6a+5wB3EfBRP0rnx1fMk6gGA0UUe4=" // Sum of ent codegen.
+)
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/predicate"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/softdeleteitem"
+)
+
+// SoftDeleteItemDelete is the builder for deleting a SoftDeleteItem entity.
+type SoftDeleteItemDelete struct {
+	config
+	hooks    []Hook
+	mutation *SoftDeleteItemMutation
+}
+
+// Where appends a list predicates to the SoftDeleteItemDelete builder.
+func (_d *SoftDeleteItemDelete) Where(ps ...predicate.SoftDeleteItem) *SoftDeleteItemDelete {
+	_d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (_d *SoftDeleteItemDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, _d.sqlExec, _d.mutation, _d.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *SoftDeleteItemDelete) ExecX(ctx context.Context) int {
+	n, err := _d.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (_d *SoftDeleteItemDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(softdeleteitem.Table, sqlgraph.NewFieldSpec(softdeleteitem.FieldID, field.TypeUint64))
+	if ps := _d.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, _d.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	_d.mutation.done = true
+	return affected, err
+}
+
+// SoftDeleteItemDeleteOne is the builder for deleting a single SoftDeleteItem entity.
+type SoftDeleteItemDeleteOne struct {
+	_d *SoftDeleteItemDelete
+}
+
+// Where appends a list predicates to the SoftDeleteItemDelete builder.
+func (_d *SoftDeleteItemDeleteOne) Where(ps ...predicate.SoftDeleteItem) *SoftDeleteItemDeleteOne {
+	_d._d.mutation.Where(ps...)
+	return _d
+}
+
+// Exec executes the deletion query.
+func (_d *SoftDeleteItemDeleteOne) Exec(ctx context.Context) error {
+	n, err := _d._d.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{softdeleteitem.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_d *SoftDeleteItemDeleteOne) ExecX(ctx context.Context) {
+	if err := _d.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,310 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/predicate"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/softdeleteitem"
+)
+
+// SoftDeleteItemUpdate is the builder for updating SoftDeleteItem entities.
+type SoftDeleteItemUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SoftDeleteItemMutation
+}
+
+// Where appends a list predicates to the SoftDeleteItemUpdate builder.
+func (_u *SoftDeleteItemUpdate) Where(ps ...predicate.SoftDeleteItem) *SoftDeleteItemUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *SoftDeleteItemUpdate) SetUpdatedAt(v time.Time) *SoftDeleteItemUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (_u *SoftDeleteItemUpdate) SetDeletedAt(v time.Time) *SoftDeleteItemUpdate {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_u *SoftDeleteItemUpdate) SetNillableDeletedAt(v *time.Time) *SoftDeleteItemUpdate {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (_u *SoftDeleteItemUpdate) ClearDeletedAt() *SoftDeleteItemUpdate {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *SoftDeleteItemUpdate) SetName(v string) *SoftDeleteItemUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *SoftDeleteItemUpdate) SetNillableName(v *string) *SoftDeleteItemUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// Mutation returns the SoftDeleteItemMutation object of the builder.
+func (_u *SoftDeleteItemUpdate) Mutation() *SoftDeleteItemMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *SoftDeleteItemUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SoftDeleteItemUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *SoftDeleteItemUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SoftDeleteItemUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *SoftDeleteItemUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if softdeleteitem.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized softdeleteitem.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := softdeleteitem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (_u *SoftDeleteItemUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(softdeleteitem.Table, softdeleteitem.Columns, sqlgraph.NewFieldSpec(softdeleteitem.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(softdeleteitem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(softdeleteitem.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(softdeleteitem.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(softdeleteitem.FieldName, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{softdeleteitem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// SoftDeleteItemUpdateOne is the builder for updating a single SoftDeleteItem entity.
+type SoftDeleteItemUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SoftDeleteItemMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *SoftDeleteItemUpdateOne) SetUpdatedAt(v time.Time) *SoftDeleteItemUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (_u *SoftDeleteItemUpdateOne) SetDeletedAt(v time.Time) *SoftDeleteItemUpdateOne {
+	_u.mutation.SetDeletedAt(v)
+	return _u
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (_u *SoftDeleteItemUpdateOne) SetNillableDeletedAt(v *time.Time) *SoftDeleteItemUpdateOne {
+	if v != nil {
+		_u.SetDeletedAt(*v)
+	}
+	return _u
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (_u *SoftDeleteItemUpdateOne) ClearDeletedAt() *SoftDeleteItemUpdateOne {
+	_u.mutation.ClearDeletedAt()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *SoftDeleteItemUpdateOne) SetName(v string) *SoftDeleteItemUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *SoftDeleteItemUpdateOne) SetNillableName(v *string) *SoftDeleteItemUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// Mutation returns the SoftDeleteItemMutation object of the builder.
+func (_u *SoftDeleteItemUpdateOne) Mutation() *SoftDeleteItemMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the SoftDeleteItemUpdate builder.
+func (_u *SoftDeleteItemUpdateOne) Where(ps ...predicate.SoftDeleteItem) *SoftDeleteItemUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *SoftDeleteItemUpdateOne) Select(field string, fields ...string) *SoftDeleteItemUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated SoftDeleteItem entity.
+func (_u *SoftDeleteItemUpdateOne) Save(ctx context.Context) (*SoftDeleteItem, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SoftDeleteItemUpdateOne) SaveX(ctx context.Context) *SoftDeleteItem {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *SoftDeleteItemUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SoftDeleteItemUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *SoftDeleteItemUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if softdeleteitem.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized softdeleteitem.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := softdeleteitem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (_u *SoftDeleteItemUpdateOne) sqlSave(ctx context.Context) (_node *SoftDeleteItem, err error) {
+	_spec := sqlgraph.NewUpdateSpec(softdeleteitem.Table, softdeleteitem.Columns, sqlgraph.NewFieldSpec(softdeleteitem.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SoftDeleteItem.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, softdeleteitem.FieldID)
+		for _, f := range fields {
+			if !softdeleteitem.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != softdeleteitem.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(softdeleteitem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.DeletedAt(); ok {
+		_spec.SetField(softdeleteitem.FieldDeletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.DeletedAtCleared() {
+		_spec.ClearField(softdeleteitem.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(softdeleteitem.FieldName, field.TypeString, value)
+	}
+	_node = &SoftDeleteItem{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{softdeleteitem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,298 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	uuid "github.com/gofrs/uuid/v5"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/uuidaudititem"
+)
+
+// UUIDAuditItemCreate is the builder for creating a UUIDAuditItem entity.
+type UUIDAuditItemCreate struct {
+	config
+	mutation *UUIDAuditItemMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *UUIDAuditItemCreate) SetCreatedAt(v time.Time) *UUIDAuditItemCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *UUIDAuditItemCreate) SetNillableCreatedAt(v *time.Time) *UUIDAuditItemCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *UUIDAuditItemCreate) SetUpdatedAt(v time.Time) *UUIDAuditItemCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *UUIDAuditItemCreate) SetNillableUpdatedAt(v *time.Time) *UUIDAuditItemCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_c *UUIDAuditItemCreate) SetCreatedBy(v uuid.UUID) *UUIDAuditItemCreate {
+	_c.mutation.SetCreatedBy(v)
+	return _c
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_c *UUIDAuditItemCreate) SetNillableCreatedBy(v *uuid.UUID) *UUIDAuditItemCreate {
+	if v != nil {
+		_c.SetCreatedBy(*v)
+	}
+	return _c
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_c *UUIDAuditItemCreate) SetUpdatedBy(v uuid.UUID) *UUIDAuditItemCreate {
+	_c.mutation.SetUpdatedBy(v)
+	return _c
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_c *UUIDAuditItemCreate) SetNillableUpdatedBy(v *uuid.UUID) *UUIDAuditItemCreate {
+	if v != nil {
+		_c.SetUpdatedBy(*v)
+	}
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *UUIDAuditItemCreate) SetName(v string) *UUIDAuditItemCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *UUIDAuditItemCreate) SetID(v uint64) *UUIDAuditItemCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the UUIDAuditItemMutation object of the builder.
+func (_c *UUIDAuditItemCreate) Mutation() *UUIDAuditItemMutation {
+	return _c.mutation
+}
+
+// Save creates the UUIDAuditItem in the database.
+func (_c *UUIDAuditItemCreate) Save(ctx context.Context) (*UUIDAuditItem, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *UUIDAuditItemCreate) SaveX(ctx context.Context) *UUIDAuditItem {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UUIDAuditItemCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UUIDAuditItemCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *UUIDAuditItemCreate) defaults() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		if uuidaudititem.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized uuidaudititem.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := uuidaudititem.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		if uuidaudititem.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized uuidaudititem.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := uuidaudititem.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *UUIDAuditItemCreate) check() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "UUIDAuditItem.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "UUIDAuditItem.updated_at"`)}
+	}
+	if _, ok := _c.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "UUIDAuditItem.name"`)}
+	}
+	return nil
+}
+
+func (_c *UUIDAuditItemCreate) sqlSave(ctx context.Context) (*UUIDAuditItem, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = uint64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *UUIDAuditItemCreate) createSpec() (*UUIDAuditItem, *sqlgraph.CreateSpec) {
+	var (
+		_node = &UUIDAuditItem{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(uuidaudititem.Table, sqlgraph.NewFieldSpec(uuidaudititem.FieldID, field.TypeUint64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(uuidaudititem.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(uuidaudititem.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.CreatedBy(); ok {
+		_spec.SetField(uuidaudititem.FieldCreatedBy, field.TypeUUID, value)
+		_node.CreatedBy = value
+	}
+	if value, ok := _c.mutation.UpdatedBy(); ok {
+		_spec.SetField(uuidaudititem.FieldUpdatedBy, field.TypeUUID, value)
+		_node.UpdatedBy = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(uuidaudititem.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	return _node, _spec
+}
+
+// UUIDAuditItemCreateBulk is the builder for creating many UUIDAuditItem entities in bulk.
+type UUIDAuditItemCreateBulk struct {
+	config
+	err      error
+	builders []*UUIDAuditItemCreate
+}
+
+// Save creates the UUIDAuditItem entities in the database.
+func (_c *UUIDAuditItemCreateBulk) Save(ctx context.Context) ([]*UUIDAuditItem, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*UUIDAuditItem, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*UUIDAuditItemMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = uint64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *UUIDAuditItemCreateBulk) SaveX(ctx context.Context) []*UUIDAuditItem {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *UUIDAuditItemCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *UUIDAuditItemCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
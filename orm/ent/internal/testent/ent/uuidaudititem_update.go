@@ -0,0 +1,317 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	uuid "github.com/gofrs/uuid/v5"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/predicate"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/uuidaudititem"
+)
+
+// UUIDAuditItemUpdate is the builder for updating UUIDAuditItem entities.
+type UUIDAuditItemUpdate struct {
+	config
+	hooks    []Hook
+	mutation *UUIDAuditItemMutation
+}
+
+// Where appends a list predicates to the UUIDAuditItemUpdate builder.
+func (_u *UUIDAuditItemUpdate) Where(ps ...predicate.UUIDAuditItem) *UUIDAuditItemUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *UUIDAuditItemUpdate) SetUpdatedAt(v time.Time) *UUIDAuditItemUpdate {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *UUIDAuditItemUpdate) SetUpdatedBy(v uuid.UUID) *UUIDAuditItemUpdate {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *UUIDAuditItemUpdate) SetNillableUpdatedBy(v *uuid.UUID) *UUIDAuditItemUpdate {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *UUIDAuditItemUpdate) ClearUpdatedBy() *UUIDAuditItemUpdate {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *UUIDAuditItemUpdate) SetName(v string) *UUIDAuditItemUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *UUIDAuditItemUpdate) SetNillableName(v *string) *UUIDAuditItemUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// Mutation returns the UUIDAuditItemMutation object of the builder.
+func (_u *UUIDAuditItemUpdate) Mutation() *UUIDAuditItemMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *UUIDAuditItemUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UUIDAuditItemUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *UUIDAuditItemUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UUIDAuditItemUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *UUIDAuditItemUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if uuidaudititem.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized uuidaudititem.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := uuidaudititem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (_u *UUIDAuditItemUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(uuidaudititem.Table, uuidaudititem.Columns, sqlgraph.NewFieldSpec(uuidaudititem.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(uuidaudititem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(uuidaudititem.FieldCreatedBy, field.TypeUUID)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(uuidaudititem.FieldUpdatedBy, field.TypeUUID, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(uuidaudititem.FieldUpdatedBy, field.TypeUUID)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(uuidaudititem.FieldName, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{uuidaudititem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// UUIDAuditItemUpdateOne is the builder for updating a single UUIDAuditItem entity.
+type UUIDAuditItemUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *UUIDAuditItemMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *UUIDAuditItemUpdateOne) SetUpdatedAt(v time.Time) *UUIDAuditItemUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *UUIDAuditItemUpdateOne) SetUpdatedBy(v uuid.UUID) *UUIDAuditItemUpdateOne {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *UUIDAuditItemUpdateOne) SetNillableUpdatedBy(v *uuid.UUID) *UUIDAuditItemUpdateOne {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *UUIDAuditItemUpdateOne) ClearUpdatedBy() *UUIDAuditItemUpdateOne {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *UUIDAuditItemUpdateOne) SetName(v string) *UUIDAuditItemUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *UUIDAuditItemUpdateOne) SetNillableName(v *string) *UUIDAuditItemUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// Mutation returns the UUIDAuditItemMutation object of the builder.
+func (_u *UUIDAuditItemUpdateOne) Mutation() *UUIDAuditItemMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the UUIDAuditItemUpdate builder.
+func (_u *UUIDAuditItemUpdateOne) Where(ps ...predicate.UUIDAuditItem) *UUIDAuditItemUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *UUIDAuditItemUpdateOne) Select(field string, fields ...string) *UUIDAuditItemUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated UUIDAuditItem entity.
+func (_u *UUIDAuditItemUpdateOne) Save(ctx context.Context) (*UUIDAuditItem, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *UUIDAuditItemUpdateOne) SaveX(ctx context.Context) *UUIDAuditItem {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *UUIDAuditItemUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *UUIDAuditItemUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *UUIDAuditItemUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdatedAt(); !ok {
+		if uuidaudititem.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized uuidaudititem.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := uuidaudititem.UpdateDefaultUpdatedAt()
+		_u.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+func (_u *UUIDAuditItemUpdateOne) sqlSave(ctx context.Context) (_node *UUIDAuditItem, err error) {
+	_spec := sqlgraph.NewUpdateSpec(uuidaudititem.Table, uuidaudititem.Columns, sqlgraph.NewFieldSpec(uuidaudititem.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "UUIDAuditItem.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, uuidaudititem.FieldID)
+		for _, f := range fields {
+			if !uuidaudititem.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != uuidaudititem.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UpdatedAt(); ok {
+		_spec.SetField(uuidaudititem.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(uuidaudititem.FieldCreatedBy, field.TypeUUID)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(uuidaudititem.FieldUpdatedBy, field.TypeUUID, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(uuidaudititem.FieldUpdatedBy, field.TypeUUID)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(uuidaudititem.FieldName, field.TypeString, value)
+	}
+	_node = &UUIDAuditItem{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{uuidaudititem.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,56 @@
+//go:build ignore
+
+package main
+
+import (
+	"log"
+
+	"entgo.io/ent"
+	"entgo.io/ent/entc/gen"
+	"entgo.io/ent/entc/load"
+	"mingyang.com/admin-common/orm/ent/internal/testent/schema"
+)
+
+// main generates the client from the schemas in process, the schemas are not loaded by entc.LoadGraph
+// since its package loader does not support the newer go toolchains.
+func main() {
+	var schemas []*load.Schema
+	for _, v := range []ent.Interface{
+		schema.TenantItem{},
+		schema.DepartmentItem{},
+		schema.HistoryItem{},
+		schema.SoftDeleteItem{},
+		schema.AuditItem{},
+		schema.DataPermItem{},
+		schema.VersionItem{},
+		schema.UUIDAuditItem{},
+	} {
+		b, err := load.MarshalSchema(v)
+		if err != nil {
+			log.Fatal("marshaling schema:", err)
+		}
+		s, err := load.UnmarshalSchema(b)
+		if err != nil {
+			log.Fatal("unmarshaling schema:", err)
+		}
+		schemas = append(schemas, s)
+	}
+
+	storage, err := gen.NewStorage("sql")
+	if err != nil {
+		log.Fatal("loading storage:", err)
+	}
+	graph, err := gen.NewGraph(&gen.Config{
+		Storage:  storage,
+		Target:   "./ent",
+		Features: []gen.Feature{gen.FeatureExecQuery},
+		Package:  "mingyang.com/admin-common/orm/ent/internal/testent/ent",
+		Schema:   "mingyang.com/admin-common/orm/ent/internal/testent/schema",
+	}, schemas...)
+	if err != nil {
+		log.Fatal("loading graph:", err)
+	}
+	if err = graph.Gen(); err != nil {
+		log.Fatal("running ent codegen:", err)
+	}
+}
@@ -0,0 +1,4 @@
+// Package testent is the ent client generated from the schemas using the mixins, it is only used by the tests.
+package testent
+
+//go:generate go run -mod=mod entc.go
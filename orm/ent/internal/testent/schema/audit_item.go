@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// AuditItem is soft-deleted with the audit, version and history mixins, whose hooks run again
+// when the delete is mutated as the update.
+type AuditItem struct {
+	ent.Schema
+}
+
+func (AuditItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (AuditItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.IDMixin{},
+		mixins.AuditMixin{Uint64UserID: true, WithoutTime: true},
+		mixins.VersionMixin{},
+		mixins.HistoryMixin{},
+		mixins.SoftDeleteMixin{},
+	}
+}
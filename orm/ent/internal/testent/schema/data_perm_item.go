@@ -0,0 +1,27 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// DataPermItem is filtered by the data permission, and its creator is the uint64 user id.
+type DataPermItem struct {
+	ent.Schema
+}
+
+func (DataPermItem) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Uint64("department_id").Optional(),
+	}
+}
+
+func (DataPermItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.IDMixin{},
+		mixins.AuditMixin{Uint64UserID: true, WithoutTime: true},
+		mixins.DataPermMixin{Uint64UserID: true},
+	}
+}
@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// DepartmentItem is filtered by department.
+type DepartmentItem struct {
+	ent.Schema
+}
+
+func (DepartmentItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (DepartmentItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixins.IDMixin{}, mixins.DepartmentMixin{}}
+}
@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// HistoryItem records its change history.
+type HistoryItem struct {
+	ent.Schema
+}
+
+func (HistoryItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (HistoryItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixins.IDMixin{}, mixins.HistoryMixin{}}
+}
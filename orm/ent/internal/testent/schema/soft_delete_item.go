@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// SoftDeleteItem is soft-deleted.
+type SoftDeleteItem struct {
+	ent.Schema
+}
+
+func (SoftDeleteItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (SoftDeleteItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixins.IDMixin{}, mixins.SoftDeleteMixin{}}
+}
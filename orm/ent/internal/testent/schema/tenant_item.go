@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// TenantItem is isolated by tenant.
+type TenantItem struct {
+	ent.Schema
+}
+
+func (TenantItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (TenantItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixins.IDMixin{}, mixins.TenantMixin{}}
+}
@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// UUIDAuditItem records the UUID users with the audit mixin.
+type UUIDAuditItem struct {
+	ent.Schema
+}
+
+func (UUIDAuditItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (UUIDAuditItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixins.IDMixin{}, mixins.AuditMixin{WithoutTime: true}}
+}
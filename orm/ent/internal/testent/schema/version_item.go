@@ -0,0 +1,20 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// VersionItem is updated with the optimistic lock.
+type VersionItem struct {
+	ent.Schema
+}
+
+func (VersionItem) Fields() []ent.Field {
+	return []ent.Field{field.String("name")}
+}
+
+func (VersionItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{mixins.IDMixin{}, mixins.VersionMixin{}}
+}
@@ -0,0 +1,140 @@
+package mixins
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	"github.com/gofrs/uuid/v5"
+	"github.com/zeromicro/go-zero/core/errorx"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+)
+
+const (
+	// AuditCreatedByFieldName is the creator field name of AuditMixin.
+	AuditCreatedByFieldName = "created_by"
+	// AuditUpdatedByFieldName is the updater field name of AuditMixin.
+	AuditUpdatedByFieldName = "updated_by"
+)
+
+// AuditMixin for embedding the created and updated user and time info in different schemas.
+//
+// The created_by and updated_by are set by the user in userctx.GetUserIDFromCtx on create and update,
+// and the explicitly set values are kept. The system actor of userctx.WithSystemActor is saved as
+// uuid.Nil or 0, and the fields are not set if there is no user in context.
+type AuditMixin struct {
+	mixin.Schema
+	// Uint64UserID uses the uint64 user id, such as the sonyflake id, instead of the UUID.
+	Uint64UserID bool
+	// WithoutTime skips the created_at and updated_at fields, which are already in the ID mixins.
+	WithoutTime bool
+}
+
+// Fields for all schemas that embed AuditMixin.
+func (a AuditMixin) Fields() []ent.Field {
+	var fields []ent.Field
+	if !a.WithoutTime {
+		fields = append(fields,
+			field.Time("created_at").
+				Immutable().
+				Default(time.Now).
+				Comment("Create Time | 创建日期"),
+			field.Time("updated_at").
+				Default(time.Now).
+				UpdateDefault(time.Now).
+				Comment("Update Time | 修改日期"),
+		)
+	}
+
+	if a.Uint64UserID {
+		return append(fields,
+			field.Uint64(AuditCreatedByFieldName).
+				Optional().
+				Immutable().
+				Comment("Created user's ID | 创建者 ID"),
+			field.Uint64(AuditUpdatedByFieldName).
+				Optional().
+				Comment("Updated user's ID | 修改者 ID"),
+		)
+	}
+
+	return append(fields,
+		field.UUID(AuditCreatedByFieldName, uuid.UUID{}).
+			Optional().
+			Immutable().
+			Comment("Created user's UUID | 创建者 UUID"),
+		field.UUID(AuditUpdatedByFieldName, uuid.UUID{}).
+			Optional().
+			Comment("Updated user's UUID | 修改者 UUID"),
+	)
+}
+
+// Hooks of the AuditMixin.
+func (a AuditMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		mutateFunc(ent.OpCreate, func(ctx context.Context, m ent.Mutation) error {
+			return a.setActor(ctx, m, AuditCreatedByFieldName, AuditUpdatedByFieldName)
+		}),
+		mutateFunc(ent.OpUpdate|ent.OpUpdateOne, func(ctx context.Context, m ent.Mutation) error {
+			return a.setActor(ctx, m, AuditUpdatedByFieldName)
+		}),
+	}
+}
+
+// setActor sets the user in context to the fields which are not set.
+func (a AuditMixin) setActor(ctx context.Context, m ent.Mutation, fields ...string) error {
+	var unset []string
+	for _, v := range fields {
+		if _, ok := m.Field(v); !ok {
+			unset = append(unset, v)
+		}
+	}
+	if len(unset) == common.Zero {
+		return nil
+	}
+
+	actor, ok, err := a.actor(ctx)
+	if err != nil || !ok {
+		return err
+	}
+
+	for _, v := range unset {
+		if err = m.SetField(v, actor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// actor returns the user id in context as the field type, ok is false if there is no user.
+func (a AuditMixin) actor(ctx context.Context) (ent.Value, bool, error) {
+	if userctx.IsSystemActor(ctx) {
+		if a.Uint64UserID {
+			return uint64(0), true, nil
+		}
+		return uuid.Nil, true, nil
+	}
+
+	userId, err := userctx.GetUserIDFromCtx(ctx)
+	if err != nil || userId == common.EmptyString {
+		return nil, false, nil
+	}
+
+	if a.Uint64UserID {
+		id, err := strconv.ParseUint(userId, common.Ten, 64)
+		if err != nil {
+			return nil, false, errorx.NewInvalidArgumentError("invalid user id")
+		}
+		return id, true, nil
+	}
+
+	id, err := uuid.FromString(userId)
+	if err != nil {
+		return nil, false, errorx.NewInvalidArgumentError("invalid user id")
+	}
+	return id, true, nil
+}
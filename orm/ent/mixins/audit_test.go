@@ -0,0 +1,77 @@
+package mixins_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/audititem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/uuidaudititem"
+)
+
+func TestAuditMixinUUID(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+	u1, u2 := uuid.Must(uuid.NewV7()), uuid.Must(uuid.NewV7())
+	c1 := context.WithValue(ctx, common.CtxKeyUserID, u1.String())
+	c2 := context.WithValue(ctx, common.CtxKeyUserID, u2.String())
+
+	a := client.UUIDAuditItem.Create().SetName("a").SaveX(c1)
+	assert.Equal(t, u1, a.CreatedBy)
+	assert.Equal(t, u1, a.UpdatedBy)
+
+	// created_by is kept on update
+	a = client.UUIDAuditItem.UpdateOne(a).SetName("b").SaveX(c2)
+	assert.Equal(t, u1, a.CreatedBy)
+	assert.Equal(t, u2, a.UpdatedBy)
+	assert.Equal(t, 1, client.UUIDAuditItem.Update().Where(uuidaudititem.ID(a.ID)).SetName("c").SaveX(c1))
+	assert.Equal(t, u1, client.UUIDAuditItem.GetX(ctx, a.ID).UpdatedBy)
+
+	// the explicitly set value is kept
+	a = client.UUIDAuditItem.UpdateOne(a).SetName("d").SetUpdatedBy(u2).SaveX(c1)
+	assert.Equal(t, u2, a.UpdatedBy)
+
+	// the system actor is saved as uuid.Nil and no user leaves the fields null
+	system := client.UUIDAuditItem.Create().SetName("system").SaveX(userctx.WithSystemActor(ctx))
+	assert.Equal(t, 1, client.UUIDAuditItem.Query().Where(uuidaudititem.ID(system.ID),
+		uuidaudititem.CreatedBy(uuid.Nil), uuidaudititem.UpdatedBy(uuid.Nil)).CountX(ctx))
+	anonymous := client.UUIDAuditItem.Create().SetName("anonymous").SaveX(ctx)
+	assert.Equal(t, 1, client.UUIDAuditItem.Query().Where(uuidaudititem.ID(anonymous.ID),
+		uuidaudititem.CreatedByIsNil(), uuidaudititem.UpdatedByIsNil()).CountX(ctx))
+
+	// the user id must be the UUID
+	_, err := client.UUIDAuditItem.Create().SetName("e").Save(context.WithValue(ctx, common.CtxKeyUserID, "12"))
+	assert.NotNil(t, err)
+}
+
+func TestAuditMixinUint64(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+	c12 := context.WithValue(ctx, common.CtxKeyUserID, "12")
+	c13 := context.WithValue(ctx, common.CtxKeyUserID, "13")
+
+	a := client.AuditItem.Create().SetName("a").SaveX(c12)
+	assert.Equal(t, uint64(12), a.CreatedBy)
+	assert.Equal(t, uint64(12), a.UpdatedBy)
+
+	// created_by is kept on update
+	assert.Equal(t, 1, client.AuditItem.Update().Where(audititem.ID(a.ID)).SetName("b").SaveX(c13))
+	a = client.AuditItem.GetX(ctx, a.ID)
+	assert.Equal(t, uint64(12), a.CreatedBy)
+	assert.Equal(t, uint64(13), a.UpdatedBy)
+
+	// the system actor is saved as 0
+	system := client.AuditItem.Create().SetName("system").SaveX(userctx.WithSystemActor(ctx))
+	assert.Equal(t, 1, client.AuditItem.Query().Where(audititem.ID(system.ID),
+		audititem.CreatedBy(0), audititem.UpdatedBy(0)).CountX(ctx))
+
+	// the user id must be the uint64
+	_, err := client.AuditItem.Create().SetName("c").Save(context.WithValue(ctx, common.CtxKeyUserID,
+		uuid.Must(uuid.NewV7()).String()))
+	assert.NotNil(t, err)
+}
@@ -22,6 +22,7 @@ import (
 )
 
 // CreatedByMixin for embedding the created user's uuid info in different schemas.
+// The field is not set automatically, use AuditMixin to fill it from context.
 type CreatedByMixin struct {
 	mixin.Schema
 }
@@ -0,0 +1,233 @@
+package mixins
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync/atomic"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/mixin"
+	"github.com/gofrs/uuid/v5"
+	"github.com/zeromicro/go-zero/core/errorx"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/datapermctx"
+	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
+	"mingyang.com/admin-common/orm/ent/entctx/rolectx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+	"mingyang.com/admin-common/orm/ent/entenum"
+)
+
+const (
+	// DefaultDataPermDeptField is the default department field of DataPermMixin.
+	DefaultDataPermDeptField = "department_id"
+	// DefaultDataPermCreatorField is the default creator field of DataPermMixin.
+	DefaultDataPermCreatorField = "created_by"
+)
+
+var dataPermLoader atomic.Pointer[datapermctx.Loader]
+
+// SetDataPermLoader sets the loader used by DataPermMixin when the data permission is not in context,
+// such as datapermctx.NewRedisLoader.
+func SetDataPermLoader(loader datapermctx.Loader) {
+	dataPermLoader.Store(&loader)
+}
+
+func getDataPermLoader() datapermctx.Loader {
+	if loader := dataPermLoader.Load(); loader != nil {
+		return *loader
+	}
+	return nil
+}
+
+// DataPermMixin filters the queries by the data scope in context. The schema should also embed
+// DepartmentMixin and CreatedByMixin, or the fields configured by DeptField and CreatorField.
+//
+//	All               no filter
+//	CustomDept        department_id IN custom department ids
+//	OwnDeptAndSub     department_id IN own department and sub department ids
+//	OwnDept           department_id = own department id
+//	Self              created_by = user id, the UUID or the uint64 by Uint64UserID
+//
+// The department field can be replaced by datapermctx.WithFilterFieldContext.
+//
+// The data scope is read from context, or loaded by the loader set by SetDataPermLoader. Without both
+// the queries fail, unless AllowWithoutScope is set.
+type DataPermMixin struct {
+	mixin.Schema
+	// DeptField is the department field, the default is department_id.
+	DeptField string
+	// CreatorField is the creator field, the default is created_by.
+	CreatorField string
+	// Uint64UserID compares the creator field with the uint64 user id, such as the field of
+	// AuditMixin with Uint64UserID, instead of the UUID.
+	Uint64UserID bool
+	// AllowWithoutScope returns all data when there is no data scope in context and no loader is set,
+	// otherwise an error is returned.
+	AllowWithoutScope bool
+}
+
+// Interceptors of the DataPermMixin.
+func (d DataPermMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		traverseFunc(func(ctx context.Context, q predicateAppender) error {
+			field, values, err := d.Condition(ctx)
+			if err != nil || field == common.EmptyString {
+				return err
+			}
+
+			q.WhereP(sql.FieldIn(field, values...))
+			return nil
+		}),
+	}
+}
+
+// Condition returns the field and its allowed values of the data scope in context, the empty field means
+// no filter. It is also used to apply the data permission to other ORMs, such as the gorm scopes.
+func (d DataPermMixin) Condition(ctx context.Context) (string, []any, error) {
+	if datapermctx.GetIgnoreDataPermFromCtx(ctx) || tenantctx.GetTenantAdminCtx(ctx) {
+		return common.EmptyString, nil, nil
+	}
+
+	scope, err := datapermctx.GetScopeFromCtx(ctx)
+	if err != nil {
+		loader := getDataPermLoader()
+		if loader == nil {
+			if d.AllowWithoutScope {
+				return common.EmptyString, nil, nil
+			}
+			return common.EmptyString, nil, err
+		}
+
+		roleCodes, err := rolectx.GetRoleIDFromCtx(ctx)
+		if err != nil {
+			return common.EmptyString, nil, err
+		}
+		if scope, err = loader.LoadScope(ctx, roleCodes, tenantctx.GetTenantIDFromCtx(ctx)); err != nil {
+			return common.EmptyString, nil, err
+		}
+	}
+
+	switch scope {
+	case entenum.DataPermAll:
+		return common.EmptyString, nil, nil
+	case entenum.DataPermCustomDept:
+		ids, err := customDeptIds(ctx)
+		if err != nil {
+			return common.EmptyString, nil, err
+		}
+		return d.deptField(ctx), uint64Values(ids), nil
+	case entenum.DataPermOwnDeptAndSub:
+		ids, err := subDeptIds(ctx)
+		if err != nil {
+			return common.EmptyString, nil, err
+		}
+		return d.deptField(ctx), uint64Values(ids), nil
+	case entenum.DataPermOwnDept:
+		deptId, err := deptctx.GetDepartmentIDFromCtx(ctx)
+		if err != nil {
+			return common.EmptyString, nil, err
+		}
+		return d.deptField(ctx), []any{deptId}, nil
+	case entenum.DataPermSelf:
+		creator, err := d.creator(ctx)
+		if err != nil {
+			return common.EmptyString, nil, err
+		}
+		return d.creatorField(), []any{creator}, nil
+	default:
+		return common.EmptyString, nil, errorx.NewInvalidArgumentError("invalid data scope")
+	}
+}
+
+func (d DataPermMixin) deptField(ctx context.Context) string {
+	if field, err := datapermctx.GetFilterFieldFromCtx(ctx); err == nil && field != common.EmptyString {
+		return field
+	}
+	if d.DeptField != common.EmptyString {
+		return d.DeptField
+	}
+	return DefaultDataPermDeptField
+}
+
+// creator returns the user id in context as the type of the creator field.
+func (d DataPermMixin) creator(ctx context.Context) (any, error) {
+	userId, err := userctx.GetUserIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Uint64UserID {
+		id, err := strconv.ParseUint(userId, common.Ten, 64)
+		if err != nil {
+			return nil, errorx.NewInvalidArgumentError("invalid user id")
+		}
+		return id, nil
+	}
+
+	id, err := uuid.FromString(userId)
+	if err != nil {
+		return nil, errorx.NewInvalidArgumentError("invalid user id")
+	}
+	return id, nil
+}
+
+func (d DataPermMixin) creatorField() string {
+	if d.CreatorField != common.EmptyString {
+		return d.CreatorField
+	}
+	return DefaultDataPermCreatorField
+}
+
+// customDeptIds returns the custom department ids in context, or loads them by the roles.
+func customDeptIds(ctx context.Context) ([]uint64, error) {
+	ids, err := datapermctx.GetCustomDeptFromCtx(ctx)
+	if err == nil {
+		return ids, nil
+	}
+
+	loader := getDataPermLoader()
+	if loader == nil {
+		return nil, err
+	}
+
+	roleCodes, err := rolectx.GetRoleIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadCustomDept(ctx, roleCodes, tenantctx.GetTenantIDFromCtx(ctx))
+}
+
+// subDeptIds returns the own department and sub department ids in context, or loads them by the department.
+func subDeptIds(ctx context.Context) ([]uint64, error) {
+	deptId, err := deptctx.GetDepartmentIDFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := datapermctx.GetSubDeptFromCtx(ctx)
+	if err != nil {
+		loader := getDataPermLoader()
+		if loader == nil {
+			return nil, err
+		}
+		if ids, err = loader.LoadSubDept(ctx, deptId, tenantctx.GetTenantIDFromCtx(ctx)); err != nil {
+			return nil, err
+		}
+	}
+
+	if !slices.Contains(ids, deptId) {
+		ids = append(ids, deptId)
+	}
+	return ids, nil
+}
+
+func uint64Values(ids []uint64) []any {
+	values := make([]any, 0, len(ids))
+	for _, v := range ids {
+		values = append(values, v)
+	}
+	return values
+}
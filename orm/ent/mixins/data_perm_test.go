@@ -0,0 +1,111 @@
+package mixins_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/datapermctx"
+	"mingyang.com/admin-common/orm/ent/entenum"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/datapermitem"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+func TestDataPermMixinCondition(t *testing.T) {
+	self := datapermctx.WithScopeContext(context.Background(), entenum.DataPermSelfStr)
+	userId := uuid.Must(uuid.NewV7())
+
+	field, values, err := mixins.DataPermMixin{}.Condition(context.WithValue(self, common.CtxKeyUserID,
+		userId.String()))
+	assert.Nil(t, err)
+	assert.Equal(t, mixins.DefaultDataPermCreatorField, field)
+	assert.Equal(t, []any{userId}, values)
+
+	field, values, err = mixins.DataPermMixin{CreatorField: "owner_id", Uint64UserID: true}.Condition(
+		context.WithValue(self, common.CtxKeyUserID, "42"))
+	assert.Nil(t, err)
+	assert.Equal(t, "owner_id", field)
+	assert.Equal(t, []any{uint64(42)}, values)
+
+	// the user id must match the type of the creator field
+	_, _, err = mixins.DataPermMixin{}.Condition(context.WithValue(self, common.CtxKeyUserID, "42"))
+	assert.NotNil(t, err)
+	_, _, err = mixins.DataPermMixin{Uint64UserID: true}.Condition(context.WithValue(self, common.CtxKeyUserID,
+		userId.String()))
+	assert.NotNil(t, err)
+	_, _, err = mixins.DataPermMixin{Uint64UserID: true}.Condition(self)
+	assert.NotNil(t, err)
+}
+
+func TestDataPermMixinInterceptors(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+	u1 := context.WithValue(ctx, common.CtxKeyUserID, "1")
+	u2 := context.WithValue(ctx, common.CtxKeyUserID, "2")
+
+	client.DataPermItem.Create().SetName("a").SetDepartmentID(10).SaveX(u1)
+	client.DataPermItem.Create().SetName("b").SetDepartmentID(20).SaveX(u2)
+	assert.Equal(t, 2, client.DataPermItem.Query().CountX(datapermctx.WithIgnoreDataPerm(ctx)))
+
+	// the uint64 creator of the own data
+	names := client.DataPermItem.Query().Order(datapermitem.ByName()).
+		Select(datapermitem.FieldName).StringsX(datapermctx.WithScopeContext(u1, entenum.DataPermSelfStr))
+	assert.Equal(t, []string{"a"}, names)
+	names = client.DataPermItem.Query().Order(datapermitem.ByName()).
+		Select(datapermitem.FieldName).StringsX(datapermctx.WithScopeContext(u2, entenum.DataPermSelfStr))
+	assert.Equal(t, []string{"b"}, names)
+
+	// the departments
+	names = client.DataPermItem.Query().Select(datapermitem.FieldName).StringsX(datapermctx.WithCustomDeptContext(
+		datapermctx.WithScopeContext(ctx, entenum.DataPermCustomDeptStr), "20"))
+	assert.Equal(t, []string{"b"}, names)
+	assert.Equal(t, 2, client.DataPermItem.Query().CountX(datapermctx.WithScopeContext(ctx, entenum.DataPermAllStr)))
+
+	// the data permission is required
+	_, err := client.DataPermItem.Query().Count(u1)
+	assert.NotNil(t, err)
+}
+
+type testDataPermLoader struct {
+	scope uint8
+}
+
+func (l testDataPermLoader) LoadScope(context.Context, []string, uint64) (uint8, error) {
+	return l.scope, nil
+}
+
+func (l testDataPermLoader) LoadCustomDept(context.Context, []string, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func (l testDataPermLoader) LoadSubDept(context.Context, uint64, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func TestDataPermMixinWithoutScope(t *testing.T) {
+	u1 := context.WithValue(context.Background(), common.CtxKeyUserID, "1")
+	roles := context.WithValue(u1, common.CtxKeyRoleID, "admin")
+
+	// the loader is required without the data scope in context
+	_, _, err := mixins.DataPermMixin{Uint64UserID: true}.Condition(roles)
+	assert.NotNil(t, err)
+
+	field, _, err := mixins.DataPermMixin{Uint64UserID: true, AllowWithoutScope: true}.Condition(roles)
+	assert.Nil(t, err)
+	assert.Empty(t, field)
+
+	mixins.SetDataPermLoader(testDataPermLoader{scope: entenum.DataPermSelf})
+	defer mixins.SetDataPermLoader(nil)
+
+	field, values, err := mixins.DataPermMixin{Uint64UserID: true, AllowWithoutScope: true}.Condition(roles)
+	assert.Nil(t, err)
+	assert.Equal(t, mixins.DefaultDataPermCreatorField, field)
+	assert.Equal(t, []any{uint64(1)}, values)
+
+	// the roles are required by the loader
+	_, _, err = mixins.DataPermMixin{Uint64UserID: true}.Condition(u1)
+	assert.NotNil(t, err)
+}
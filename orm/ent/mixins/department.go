@@ -16,44 +16,61 @@ package mixins
 
 import (
 	"context"
+
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
-	"github.com/saas-mingyang/mingyang-admin-core/rpc/ent/intercept"
 	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
 )
 
+// DepartmentFieldName is the department field name of DepartmentMixin.
+const DepartmentFieldName = "department_id"
+
 // DepartmentMixin for embedding the department info in different schemas.
 type DepartmentMixin struct {
 	mixin.Schema
+	// AllowWithoutDepartment returns all data when there is no department in context,
+	// otherwise no data is returned.
+	AllowWithoutDepartment bool
 }
 
 // Fields for all schemas that embed DepartmentMixin.
 func (DepartmentMixin) Fields() []ent.Field {
 	return []ent.Field{
-		field.Uint64("department_id").
+		field.Uint64(DepartmentFieldName).
 			Optional().
 			Comment("Department ID | 部门 ID"),
 	}
 }
 
+// DepartmentKey skips the department filter when the context value is true.
+//
+// Deprecated: use deptctx.SkipDepartmentFilter instead.
 type DepartmentKey struct{}
 
+// Interceptors of the DepartmentMixin. The queries only return the data of the departments
+// in deptctx.GetDepartmentIDsFromCtx.
 func (d DepartmentMixin) Interceptors() []ent.Interceptor {
 	return []ent.Interceptor{
-		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
-			// Skip soft-delete, means include soft-deleted entities.
-			if skip, _ := ctx.Value(DepartmentKey{}).(bool); skip {
+		traverseFunc(func(ctx context.Context, q predicateAppender) error {
+			if skip, _ := ctx.Value(DepartmentKey{}).(bool); skip || deptctx.GetSkipDepartmentFilterFromCtx(ctx) {
 				return nil
 			}
 
-			fromCtx, err := deptctx.GetDepartmentIDFromCtx(ctx)
+			ids, err := deptctx.GetDepartmentIDsFromCtx(ctx)
 			if err != nil {
-				return err
+				if d.AllowWithoutDepartment {
+					return nil
+				}
+				q.WhereP(func(s *sql.Selector) {
+					s.Where(sql.False())
+				})
+				return nil
 			}
 
-			q.WhereP(sql.FieldIn(d.Fields()[0].Descriptor().Name, fromCtx))
+			// an empty department set returns no data
+			q.WhereP(sql.FieldIn(DepartmentFieldName, ids...))
 			return nil
 		}),
 	}
@@ -0,0 +1,48 @@
+package mixins_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/departmentitem"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+func TestDepartmentMixinInterceptors(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+	client.DepartmentItem.Create().SetName("a").SetDepartmentID(10).SaveX(ctx)
+	client.DepartmentItem.Create().SetName("b").SetDepartmentID(20).SaveX(ctx)
+	client.DepartmentItem.Create().SetName("c").SaveX(ctx)
+
+	names := func(ctx context.Context) []string {
+		return client.DepartmentItem.Query().Order(ent.Asc(departmentitem.FieldName)).
+			Select(departmentitem.FieldName).StringsX(ctx)
+	}
+	assert.Equal(t, []string{"a", "b"}, names(deptctx.WithDepartmentIDs(ctx, 10, 20)))
+	assert.Empty(t, names(deptctx.WithDepartmentIDs(ctx)))
+	// the department of the user is used without the department ids
+	assert.Equal(t, []string{"b"}, names(context.WithValue(ctx, common.CtxKeyDeptID, json.Number("20"))))
+	// no data is returned without the department
+	assert.Empty(t, names(ctx))
+	assert.Equal(t, []string{"a", "b", "c"}, names(deptctx.SkipDepartmentFilter(ctx)))
+	assert.Equal(t, []string{"a", "b", "c"}, names(context.WithValue(ctx, mixins.DepartmentKey{}, true)))
+
+	// the department ids in the incoming metadata can be forged and are ignored
+	forged := metadata.NewIncomingContext(ctx, metadata.Pairs(
+		string(deptctx.DepartmentIDsKey), "10,20", enum.DepartmentIdRpcCtxKey, "10"))
+	assert.Equal(t, []string{"a"}, names(forged))
+
+	// the counts and existence checks are filtered too
+	assert.Equal(t, 1, client.DepartmentItem.Query().CountX(deptctx.WithDepartmentIDs(ctx, 10)))
+	assert.False(t, client.DepartmentItem.Query().Where(departmentitem.Name("b")).
+		ExistX(deptctx.WithDepartmentIDs(ctx, 10)))
+}
@@ -0,0 +1,286 @@
+package mixins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/mixin"
+	"github.com/zeromicro/go-zero/core/trace"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/datapermctx"
+	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entctx/userctx"
+	"mingyang.com/admin-common/orm/ent/history"
+	"mingyang.com/admin-common/utils/uuidx"
+)
+
+var historySink atomic.Pointer[history.Sink]
+
+// SetHistorySink sets the sink of HistoryMixin, the history is not recorded before it is set.
+func SetHistorySink(sink history.Sink) {
+	historySink.Store(&sink)
+}
+
+func getHistorySink() history.Sink {
+	if sink := historySink.Load(); sink != nil {
+		return *sink
+	}
+	return nil
+}
+
+type historyKey struct{}
+
+// HistoryMixin records the change history of the schema to the sink set by SetHistorySink.
+//
+// The field values before and after each create, update and delete are recorded with the user, tenant,
+// client IP and request ID in context. The updates and deletes which do not change the data are not
+// recorded. The history is written after the mutation succeeds and its error is returned. In a transaction
+// the history.SQLSink writes the records by the transaction when the client is generated with the
+// sql/execquery feature, so they are committed or rolled back with the mutation. Otherwise, and for other
+// sinks, the records are written at once even if the transaction is rolled back later.
+// The sensitive fields are not recorded, and the ID column of the schema must be id.
+type HistoryMixin struct {
+	mixin.Schema
+}
+
+// Hooks of the HistoryMixin.
+func (HistoryMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				sink := getHistorySink()
+				// the soft delete mutates the same mutation again as the update
+				if sink == nil || ctx.Value(historyKey{}) == m {
+					return next.Mutate(ctx, m)
+				}
+				ctx = context.WithValue(ctx, historyKey{}, m)
+
+				if m.Op().Is(ent.OpCreate) {
+					v, err := next.Mutate(ctx, m)
+					if err != nil {
+						return v, err
+					}
+
+					id, data, err := entityData(v)
+					if err != nil {
+						return v, err
+					}
+					return v, writeHistory(ctx, sink, m, []*history.Record{
+						newHistoryRecord(ctx, m, history.OpCreate, id, nil, data),
+					})
+				}
+
+				ids, err := mutationIDs(historyLoadCtx(ctx), m)
+				if err != nil {
+					return nil, err
+				}
+				if len(ids) == common.Zero {
+					return next.Mutate(ctx, m)
+				}
+
+				before, err := loadEntities(ctx, m, ids)
+				if err != nil {
+					return nil, err
+				}
+
+				// the op is checked before SoftDeleteMixin converts the delete to the update
+				op := history.OpUpdate
+				if m.Op().Is(ent.OpDelete|ent.OpDeleteOne) || isSoftDeleting(ctx, m) {
+					op = history.OpDelete
+				}
+
+				v, err := next.Mutate(ctx, m)
+				if err != nil {
+					return v, err
+				}
+
+				after, err := loadEntities(ctx, m, ids)
+				if err != nil {
+					return v, err
+				}
+
+				var records []*history.Record
+				for _, id := range ids {
+					key := fmt.Sprint(id)
+					b, ok := before[key]
+					if !ok {
+						continue
+					}
+					// the soft-deleted data is kept as after, the hard-deleted data has no after
+					a, ok := after[key]
+					if ok && reflect.DeepEqual(a, b) {
+						continue
+					}
+					records = append(records, newHistoryRecord(ctx, m, op, key, b, a))
+				}
+
+				if len(records) == common.Zero {
+					return v, nil
+				}
+				return v, writeHistory(ctx, sink, m, records)
+			})
+		},
+	}
+}
+
+// writeHistory writes the records to the sink, by the transaction of the mutation if it runs in a transaction.
+func writeHistory(ctx context.Context, sink history.Sink, m ent.Mutation, records []*history.Record) error {
+	if ex, ok := mutationTxExecer(m); ok {
+		ctx = history.WithExecer(ctx, ex)
+	}
+	return sink.Write(ctx, records)
+}
+
+// mutationTxExecer returns the mutation as the history.Execer if it runs in a transaction.
+// The generated mutations implement the Execer when the sql/execquery feature of entc is enabled.
+func mutationTxExecer(m ent.Mutation) (history.Execer, bool) {
+	ex, ok := m.(history.Execer)
+	if !ok {
+		return nil, false
+	}
+
+	method := reflect.ValueOf(m).MethodByName("Tx")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 2 {
+		return nil, false
+	}
+	// Tx returns an error if the mutation is not running in a transaction
+	if err, _ := method.Call(nil)[1].Interface().(error); err != nil {
+		return nil, false
+	}
+	return ex, true
+}
+
+// newHistoryRecord returns the record with the user, tenant, client IP and request ID in context.
+func newHistoryRecord(ctx context.Context, m ent.Mutation, op history.Op, id string, before,
+	after map[string]any,
+) *history.Record {
+	userId, _ := userctx.GetUserIDFromCtx(ctx)
+	if userctx.IsSystemActor(ctx) {
+		userId = "system"
+	}
+
+	return &history.Record{
+		Id:        uuidx.NewUUID().String(),
+		Entity:    m.Type(),
+		EntityId:  id,
+		Op:        op,
+		Before:    before,
+		After:     after,
+		UserId:    userId,
+		TenantId:  tenantctx.GetTenantIDFromCtx(ctx),
+		ClientIp:  clientIPFromCtx(ctx),
+		RequestId: trace.TraceIDFromContext(ctx),
+		CreatedAt: time.Now(),
+	}
+}
+
+func clientIPFromCtx(ctx context.Context) string {
+	if ip, ok := ctx.Value(enum.ClientIPCtxKey).(string); ok {
+		return ip
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if data := md.Get(enum.ClientIPCtxKey); len(data) > 0 {
+			return data[0]
+		}
+	}
+	return common.EmptyString
+}
+
+// mutationIDs returns the IDs affected by the update or delete mutation.
+func mutationIDs(ctx context.Context, m ent.Mutation) ([]any, error) {
+	method := reflect.ValueOf(m).MethodByName("IDs")
+	if !method.IsValid() || method.Type().NumIn() != 1 || method.Type().NumOut() != 2 {
+		return nil, fmt.Errorf("unexpected mutation type %T", m)
+	}
+
+	out := method.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	ids := make([]any, 0, out[0].Len())
+	for i := 0; i < out[0].Len(); i++ {
+		ids = append(ids, out[0].Index(i).Interface())
+	}
+	return ids, nil
+}
+
+// loadEntities returns the field values of the entities by ID.
+func loadEntities(ctx context.Context, m ent.Mutation, ids []any) (map[string]map[string]any, error) {
+	client, err := mutationClientValue(m)
+	if err != nil {
+		return nil, err
+	}
+
+	// such as client.User.Query()
+	typeClient := reflect.Indirect(client).FieldByName(m.Type())
+	if !typeClient.IsValid() {
+		return nil, fmt.Errorf("unexpected mutation type %T", m)
+	}
+	query, ok := typeClient.MethodByName("Query").Call(nil)[0].Interface().(ent.Query)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T", m)
+	}
+
+	p, err := queryPredicateAppender(query)
+	if err != nil {
+		return nil, err
+	}
+	p.WhereP(sql.FieldIn("id", ids...))
+
+	out := reflect.ValueOf(query).MethodByName("All").Call([]reflect.Value{reflect.ValueOf(historyLoadCtx(ctx))})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]any, out[0].Len())
+	for i := 0; i < out[0].Len(); i++ {
+		id, data, err := entityData(out[0].Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		result[id] = data
+	}
+	return result, nil
+}
+
+// historyLoadCtx returns the context which loads the data affected by the mutation, the tenant filter is kept.
+func historyLoadCtx(ctx context.Context) context.Context {
+	ctx = IncludeDeleted(ctx)
+	ctx = deptctx.SkipDepartmentFilter(ctx)
+	return datapermctx.WithIgnoreDataPerm(ctx)
+}
+
+// entityData returns the ID and the field values of the generated entity.
+func entityData(v ent.Value) (string, map[string]any, error) {
+	entity := reflect.Indirect(reflect.ValueOf(v))
+	if entity.Kind() != reflect.Struct || !entity.FieldByName("ID").IsValid() {
+		return common.EmptyString, nil, fmt.Errorf("unexpected entity type %T", v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return common.EmptyString, nil, err
+	}
+
+	var data map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err = decoder.Decode(&data); err != nil {
+		return common.EmptyString, nil, err
+	}
+	delete(data, "edges")
+
+	return fmt.Sprint(entity.FieldByName("ID").Interface()), data, nil
+}
@@ -20,12 +20,16 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
+	"mingyang.com/admin-common/utils/idgen"
 )
 
 // IDMixin is the mixin with uint64 type ID field
 // and the created_at, updated_at fields.
 type IDMixin struct {
 	mixin.Schema
+	// Generator sets the ID on create if it is not set, the ID is set by the caller or
+	// the database if it is nil.
+	Generator idgen.IDGenerator[uint64]
 }
 
 func (IDMixin) Fields() []ent.Field {
@@ -41,3 +45,8 @@ func (IDMixin) Fields() []ent.Field {
 			Comment("Update Time | 修改日期"),
 	}
 }
+
+// Hooks of the IDMixin.
+func (m IDMixin) Hooks() []ent.Hook {
+	return idGeneratorHooks(m.Generator)
+}
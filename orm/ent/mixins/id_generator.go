@@ -0,0 +1,43 @@
+package mixins
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"entgo.io/ent"
+	"mingyang.com/admin-common/utils/idgen"
+)
+
+// idGeneratorHooks returns the hook which sets the ID by the generator on create if it is not set,
+// there is no hook if the generator is nil.
+func idGeneratorHooks[T any](g idgen.IDGenerator[T]) []ent.Hook {
+	if g == nil {
+		return nil
+	}
+
+	return []ent.Hook{
+		mutateFunc(ent.OpCreate, func(ctx context.Context, m ent.Mutation) error {
+			mv := reflect.ValueOf(m)
+			getID, setID := mv.MethodByName("ID"), mv.MethodByName("SetID")
+			if !getID.IsValid() || !setID.IsValid() || setID.Type().NumIn() != 1 {
+				return fmt.Errorf("unexpected mutation type %T", m)
+			}
+			if out := getID.Call(nil); len(out) == 2 && out[1].Bool() {
+				return nil
+			}
+
+			id, err := g.NextID(ctx)
+			if err != nil {
+				return err
+			}
+
+			v := reflect.ValueOf(id)
+			if !v.Type().ConvertibleTo(setID.Type().In(0)) {
+				return fmt.Errorf("unexpected id type %T of mutation %T", id, m)
+			}
+			setID.Call([]reflect.Value{v.Convert(setID.Type().In(0))})
+			return nil
+		}),
+	}
+}
@@ -20,12 +20,16 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
+	"mingyang.com/admin-common/utils/idgen"
 )
 
 // IDInt64Mixin is the mixin with int64 type ID field
 // and the created_at, updated_at fields.
 type IDInt64Mixin struct {
 	mixin.Schema
+	// Generator sets the ID on create if it is not set, the ID is set by the caller or
+	// the database if it is nil.
+	Generator idgen.IDGenerator[int64]
 }
 
 func (IDInt64Mixin) Fields() []ent.Field {
@@ -41,3 +45,8 @@ func (IDInt64Mixin) Fields() []ent.Field {
 			Comment("Update Time | 修改日期"),
 	}
 }
+
+// Hooks of the IDInt64Mixin.
+func (m IDInt64Mixin) Hooks() []ent.Hook {
+	return idGeneratorHooks(m.Generator)
+}
@@ -1,24 +1,25 @@
 package mixins
 
 import (
+	"time"
+
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
-	"mingyang.com/admin-common/utils/sonyflake"
-	"time"
+	"mingyang.com/admin-common/utils/idgen"
 )
 
-// IdSonyFlakeMixin id生成器
+// IdSonyFlakeMixin id生成器, 使用 sonyflake 默认实例, 多副本部署时通过 sonyflake.Conf.MustSetup 分配机器 ID
 type IdSonyFlakeMixin struct {
 	mixin.Schema
+	// Generator 替换默认实例, 如 idgen.Sonyflake(sf) 或 idgen.NewSegment
+	Generator idgen.IDGenerator[uint64]
 }
 
 // Fields of the IdSonyFlakeMixin.
 func (IdSonyFlakeMixin) Fields() []ent.Field {
 	return []ent.Field{
-		field.Uint64("id").DefaultFunc(func() uint64 {
-			return sonyflake.NextID()
-		}),
+		field.Uint64("id"),
 		field.Time("created_at").
 			Immutable().
 			Default(time.Now).
@@ -29,3 +30,13 @@ func (IdSonyFlakeMixin) Fields() []ent.Field {
 			Comment("Update Time | 修改日期"),
 	}
 }
+
+// Hooks of the IdSonyFlakeMixin, the ID is set by the hook so the errors of the generator such as
+// the lost worker id lease fail the mutation.
+func (m IdSonyFlakeMixin) Hooks() []ent.Hook {
+	g := m.Generator
+	if g == nil {
+		g = idgen.Sonyflake(nil)
+	}
+	return idGeneratorHooks(g)
+}
@@ -20,12 +20,16 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
+	"mingyang.com/admin-common/utils/idgen"
 )
 
 // IDStringMixin is the mixin with string type ID field
 // and the created_at, updated_at fields.
 type IDStringMixin struct {
 	mixin.Schema
+	// Generator sets the ID on create if it is not set, the ID is set by the caller or
+	// the database if it is nil.
+	Generator idgen.IDGenerator[string]
 }
 
 func (IDStringMixin) Fields() []ent.Field {
@@ -41,3 +45,8 @@ func (IDStringMixin) Fields() []ent.Field {
 			Comment("Update Time | 修改日期"),
 	}
 }
+
+// Hooks of the IDStringMixin.
+func (m IDStringMixin) Hooks() []ent.Hook {
+	return idGeneratorHooks(m.Generator)
+}
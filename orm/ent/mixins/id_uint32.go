@@ -20,12 +20,16 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
+	"mingyang.com/admin-common/utils/idgen"
 )
 
 // IDUint32Mixin is the mixin with Uint32 type ID field
 // and the created_at, updated_at fields.
 type IDUint32Mixin struct {
 	mixin.Schema
+	// Generator sets the ID on create if it is not set, the ID is set by the caller or
+	// the database if it is nil.
+	Generator idgen.IDGenerator[uint32]
 }
 
 func (IDUint32Mixin) Fields() []ent.Field {
@@ -41,3 +45,8 @@ func (IDUint32Mixin) Fields() []ent.Field {
 			Comment("Update Time | 修改日期"),
 	}
 }
+
+// Hooks of the IDUint32Mixin.
+func (m IDUint32Mixin) Hooks() []ent.Hook {
+	return idGeneratorHooks(m.Generator)
+}
@@ -0,0 +1,75 @@
+package mixins
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// predicateAppender is implemented by the generated queries and mutations, so the mixins
+// can add predicates without depending on a generated package.
+type predicateAppender interface {
+	WhereP(...func(*sql.Selector))
+}
+
+// traverseFunc returns the interceptor which appends predicates before the query and its traversals.
+func traverseFunc(f func(ctx context.Context, q predicateAppender) error) ent.Interceptor {
+	return ent.TraverseFunc(func(ctx context.Context, q ent.Query) error {
+		p, err := queryPredicateAppender(q)
+		if err != nil {
+			return err
+		}
+		return f(ctx, p)
+	})
+}
+
+// queryPredicateAppender returns the predicate appender of the query. The generated queries only
+// have WhereP with the intercept feature, otherwise the predicates are passed to the Where method.
+func queryPredicateAppender(q ent.Query) (predicateAppender, error) {
+	if p, ok := q.(predicateAppender); ok {
+		return p, nil
+	}
+
+	where := reflect.ValueOf(q).MethodByName("Where")
+	if !where.IsValid() || !where.Type().IsVariadic() || where.Type().NumIn() != 1 {
+		return nil, fmt.Errorf("unexpected query type %T", q)
+	}
+
+	// the generated predicate types, such as predicate.User, are defined as func(*sql.Selector)
+	predicate := where.Type().In(0).Elem()
+	if !reflect.TypeOf(func(*sql.Selector) {}).ConvertibleTo(predicate) {
+		return nil, fmt.Errorf("unexpected query type %T", q)
+	}
+	return queryWhere{where: where, predicate: predicate}, nil
+}
+
+// queryWhere appends predicates by the Where method of the generated queries.
+type queryWhere struct {
+	where     reflect.Value
+	predicate reflect.Type
+}
+
+func (q queryWhere) WhereP(ps ...func(*sql.Selector)) {
+	args := reflect.MakeSlice(reflect.SliceOf(q.predicate), 0, len(ps))
+	for _, p := range ps {
+		args = reflect.Append(args, reflect.ValueOf(p).Convert(q.predicate))
+	}
+	q.where.CallSlice([]reflect.Value{args})
+}
+
+// mutateFunc returns the hook which runs f before the mutations with the given operations.
+func mutateFunc(op ent.Op, f func(ctx context.Context, m ent.Mutation) error) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if m.Op().Is(op) {
+				if err := f(ctx, m); err != nil {
+					return nil, err
+				}
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package mixins
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// SoftDeleteFieldName is the delete time field name of SoftDeleteMixin.
+const SoftDeleteFieldName = "deleted_at"
+
+type softDeleteKey string
+
+const (
+	includeDeletedKey softDeleteKey = "soft-delete-include"
+	hardDeleteKey     softDeleteKey = "soft-delete-hard"
+	// softDeletingKey stores the delete mutation which is converted to the update
+	softDeletingKey softDeleteKey = "soft-delete-mutation"
+)
+
+// IncludeDeleted returns context which includes the soft-deleted data in the queries and updates.
+func IncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey, true)
+}
+
+// WithHardDelete returns context which deletes the data from the database instead of setting deleted_at.
+func WithHardDelete(ctx context.Context) context.Context {
+	return context.WithValue(ctx, hardDeleteKey, true)
+}
+
+// IsIncludeDeleted returns true if the context is returned by IncludeDeleted.
+func IsIncludeDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey).(bool)
+	return include
+}
+
+// IsHardDelete returns true if the context is returned by WithHardDelete.
+func IsHardDelete(ctx context.Context) bool {
+	hard, _ := ctx.Value(hardDeleteKey).(bool)
+	return hard
+}
+
+// isSoftDeleting returns true if the update mutation is converted from the delete by SoftDeleteMixin.
+func isSoftDeleting(ctx context.Context, m ent.Mutation) bool {
+	return ctx.Value(softDeletingKey) == m
+}
+
+// SoftDeleteMixin for embedding the soft delete info in different schemas.
+//
+// The deletes set deleted_at instead of removing the data, and the queries and updates skip the
+// soft-deleted data. Use IncludeDeleted to access the soft-deleted data, WithHardDelete to remove
+// the data and Restore to recover the soft-deleted data.
+type SoftDeleteMixin struct {
+	mixin.Schema
+}
+
+// Fields for all schemas that embed SoftDeleteMixin.
+func (SoftDeleteMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time(SoftDeleteFieldName).
+			Optional().
+			Nillable().
+			Comment("Delete Time | 删除时间"),
+	}
+}
+
+// Interceptors of the SoftDeleteMixin.
+func (SoftDeleteMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		traverseFunc(func(ctx context.Context, q predicateAppender) error {
+			if IsIncludeDeleted(ctx) {
+				return nil
+			}
+
+			q.WhereP(sql.FieldIsNull(SoftDeleteFieldName))
+			return nil
+		}),
+	}
+}
+
+// softDeleteMutation is implemented by the generated mutations.
+type softDeleteMutation interface {
+	predicateAppender
+	SetOp(ent.Op)
+	SetField(string, ent.Value) error
+}
+
+// Hooks of the SoftDeleteMixin.
+func (SoftDeleteMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		mutateFunc(ent.OpUpdate|ent.OpUpdateOne, func(ctx context.Context, m ent.Mutation) error {
+			if IsIncludeDeleted(ctx) {
+				return nil
+			}
+
+			p, ok := m.(predicateAppender)
+			if !ok {
+				return fmt.Errorf("unexpected mutation type %T", m)
+			}
+			p.WhereP(sql.FieldIsNull(SoftDeleteFieldName))
+			return nil
+		}),
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				if !m.Op().Is(ent.OpDelete|ent.OpDeleteOne) || IsHardDelete(ctx) {
+					return next.Mutate(ctx, m)
+				}
+
+				mx, ok := m.(softDeleteMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				client, err := mutationClient(m)
+				if err != nil {
+					return nil, err
+				}
+
+				// the delete builders expect the affected rows, which is also returned by the update builders,
+				// and the predicates of DeleteOne already contain the ID
+				mx.SetOp(ent.OpUpdate)
+				if err = mx.SetField(SoftDeleteFieldName, time.Now()); err != nil {
+					return nil, err
+				}
+				return client.Mutate(context.WithValue(ctx, softDeletingKey, m), m)
+			})
+		},
+	}
+}
+
+// Restore clears deleted_at of the soft-deleted data matched by the update builder,
+// and returns the affected rows.
+//
+//	n, err := mixins.Restore(ctx, client.User.Update().Where(user.IDEQ(id)))
+func Restore[M ent.Mutation, U interface {
+	Mutation() M
+	Save(context.Context) (int, error)
+}](ctx context.Context, update U) (int, error) {
+	m := update.Mutation()
+	if err := m.ClearField(SoftDeleteFieldName); err != nil {
+		return 0, err
+	}
+
+	if p, ok := ent.Mutation(m).(predicateAppender); ok {
+		p.WhereP(sql.FieldNotNull(SoftDeleteFieldName))
+	}
+	return update.Save(IncludeDeleted(ctx))
+}
+
+// mutator is implemented by the generated clients.
+type mutator interface {
+	Mutate(context.Context, ent.Mutation) (ent.Value, error)
+}
+
+// mutationClient returns the generated client of the mutation, which dispatches the mutation by its operation.
+func mutationClient(m ent.Mutation) (mutator, error) {
+	client, err := mutationClientValue(m)
+	if err != nil {
+		return nil, err
+	}
+
+	c, ok := client.Interface().(mutator)
+	if !ok {
+		return nil, fmt.Errorf("unexpected mutation type %T", m)
+	}
+	return c, nil
+}
+
+// mutationClientValue returns the generated client of the mutation by its Client method.
+func mutationClientValue(m ent.Mutation) (reflect.Value, error) {
+	method := reflect.ValueOf(m).MethodByName("Client")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return reflect.Value{}, fmt.Errorf("unexpected mutation type %T", m)
+	}
+	return method.Call(nil)[0], nil
+}
@@ -0,0 +1,114 @@
+package mixins_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/history"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/audititem"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/softdeleteitem"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+func TestSoftDeleteMixin(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	a := client.SoftDeleteItem.Create().SetName("a").SaveX(ctx)
+	b := client.SoftDeleteItem.Create().SetName("b").SaveX(ctx)
+	c := client.SoftDeleteItem.Create().SetName("c").SaveX(ctx)
+
+	// the delete is converted to the update of deleted_at
+	assert.Nil(t, client.SoftDeleteItem.DeleteOne(a).Exec(ctx))
+	assert.True(t, ent.IsNotFound(client.SoftDeleteItem.DeleteOne(a).Exec(ctx)))
+	n, err := client.SoftDeleteItem.Delete().Where(softdeleteitem.Name("b")).Exec(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	deleted := client.SoftDeleteItem.Query().Where(softdeleteitem.ID(a.ID)).OnlyX(mixins.IncludeDeleted(ctx))
+	assert.NotNil(t, deleted.DeletedAt)
+
+	// the queries and updates skip the soft-deleted data
+	assert.Equal(t, []uint64{c.ID}, client.SoftDeleteItem.Query().IDsX(ctx))
+	n, err = client.SoftDeleteItem.Update().SetName("x").Save(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	assert.True(t, ent.IsNotFound(client.SoftDeleteItem.UpdateOne(a).SetName("x").Exec(ctx)))
+
+	// IncludeDeleted accesses the soft-deleted data
+	assert.Equal(t, 3, client.SoftDeleteItem.Query().CountX(mixins.IncludeDeleted(ctx)))
+	assert.Nil(t, client.SoftDeleteItem.UpdateOne(a).SetName("y").Exec(mixins.IncludeDeleted(ctx)))
+
+	// Restore clears deleted_at of the soft-deleted data only
+	n, err = mixins.Restore(ctx, client.SoftDeleteItem.Update().Where(softdeleteitem.IDIn(a.ID, c.ID)))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	restored := client.SoftDeleteItem.GetX(ctx, a.ID)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, "y", restored.Name)
+
+	// WithHardDelete removes the data including the soft-deleted
+	n, err = client.SoftDeleteItem.Delete().Where(softdeleteitem.IDIn(a.ID, b.ID)).
+		Exec(mixins.WithHardDelete(mixins.IncludeDeleted(ctx)))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []uint64{c.ID}, client.SoftDeleteItem.Query().IDsX(mixins.IncludeDeleted(ctx)))
+
+	// the soft delete in a transaction is rolled back with it
+	tx, err := client.Tx(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, tx.SoftDeleteItem.DeleteOneID(c.ID).Exec(ctx))
+	assert.Nil(t, tx.Rollback())
+	assert.Equal(t, []uint64{c.ID}, client.SoftDeleteItem.Query().IDsX(ctx))
+}
+
+func TestSoftDeleteMixinWithHooks(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+
+	var (
+		mu      sync.Mutex
+		records []*history.Record
+	)
+	mixins.SetHistorySink(history.SinkFunc(func(_ context.Context, v []*history.Record) error {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, v...)
+		return nil
+	}))
+	t.Cleanup(func() {
+		mixins.SetHistorySink(nil)
+	})
+
+	creator := context.WithValue(context.Background(), common.CtxKeyUserID, "1")
+	deleter := context.WithValue(context.Background(), common.CtxKeyUserID, "2")
+	item := client.AuditItem.Create().SetName("a").SaveX(creator)
+	assert.Equal(t, uint64(1), item.UpdatedBy)
+
+	// the delete mutated again as the update runs the hooks of the update
+	assert.Nil(t, client.AuditItem.DeleteOne(item).Exec(deleter))
+	deleted := client.AuditItem.Query().Where(audititem.ID(item.ID)).OnlyX(mixins.IncludeDeleted(creator))
+	assert.NotNil(t, deleted.DeletedAt)
+	assert.Equal(t, uint64(1), deleted.CreatedBy)
+	assert.Equal(t, uint64(2), deleted.UpdatedBy)
+	assert.Equal(t, item.Version+1, deleted.Version)
+
+	// the history of the delete is recorded once
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, history.OpCreate, records[0].Op)
+		assert.Equal(t, history.OpDelete, records[1].Op)
+		assert.Equal(t, "2", records[1].UserId)
+		assert.Nil(t, records[1].Before["deleted_at"])
+		assert.NotNil(t, records[1].After["deleted_at"])
+	}
+
+	// the version of the soft-deleted data is not checked by the delete
+	assert.True(t, ent.IsNotFound(client.AuditItem.DeleteOne(item).Exec(deleter)))
+	assert.Len(t, records, 2)
+}
@@ -15,12 +15,20 @@
 package mixins
 
 import (
+	"context"
+	"fmt"
+
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
 	"mingyang.com/admin-common/orm/ent/entenum"
 )
 
+// TenantFieldName is the tenant field name of TenantMixin.
+const TenantFieldName = "tenant_id"
+
 // TenantMixin for embedding the tenant info in different schemas.
 type TenantMixin struct {
 	mixin.Schema
@@ -29,8 +37,54 @@ type TenantMixin struct {
 // Fields for all schemas that embed TenantMixin.
 func (TenantMixin) Fields() []ent.Field {
 	return []ent.Field{
-		field.Uint64("tenant_id").
+		field.Uint64(TenantFieldName).
 			Default(entenum.TenantDefaultId).
 			Immutable().Comment("Tenant ID | 租户 ID"),
 	}
 }
+
+// Interceptors of the TenantMixin. The queries only return the data of the tenant in context.
+func (TenantMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		traverseFunc(func(ctx context.Context, q predicateAppender) error {
+			if skipTenantFilter(ctx) {
+				return nil
+			}
+
+			q.WhereP(sql.FieldEQ(TenantFieldName, tenantctx.GetTenantIDFromCtx(ctx)))
+			return nil
+		}),
+	}
+}
+
+// Hooks of the TenantMixin. The tenant ID in context is set on create, and the update and delete
+// only affect the data of the tenant in context, the data of other tenants is treated as not found.
+func (TenantMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		mutateFunc(ent.OpCreate, func(ctx context.Context, m ent.Mutation) error {
+			if skipTenantFilter(ctx) {
+				return nil
+			}
+
+			// the default tenant ID is set before the hooks, so it is always overwritten by the tenant in context
+			return m.SetField(TenantFieldName, tenantctx.GetTenantIDFromCtx(ctx))
+		}),
+		mutateFunc(ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne, func(ctx context.Context, m ent.Mutation) error {
+			if skipTenantFilter(ctx) {
+				return nil
+			}
+
+			p, ok := m.(predicateAppender)
+			if !ok {
+				return fmt.Errorf("unexpected mutation type %T", m)
+			}
+			p.WhereP(sql.FieldEQ(TenantFieldName, tenantctx.GetTenantIDFromCtx(ctx)))
+			return nil
+		}),
+	}
+}
+
+// skipTenantFilter returns true when the tenant filter is ignored or the context has tenant admin authority.
+func skipTenantFilter(ctx context.Context) bool {
+	return tenantctx.GetIgnoreTenantFromCtx(ctx) || tenantctx.GetTenantAdminCtx(ctx)
+}
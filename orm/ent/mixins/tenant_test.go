@@ -0,0 +1,75 @@
+package mixins_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/rest/enum"
+	"google.golang.org/grpc/metadata"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entenum"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/enttest"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/tenantitem"
+)
+
+func openClient(t *testing.T) *ent.Client {
+	return enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+}
+
+func TestTenantMixinHooks(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+	t2, t3 := tenantctx.WithTenantId(ctx, 2), tenantctx.WithTenantId(ctx, 3)
+
+	// the tenant in context overwrites the tenant of the data
+	a := client.TenantItem.Create().SetName("a").SetTenantID(3).SaveX(t2)
+	assert.Equal(t, uint64(2), a.TenantID)
+	b := client.TenantItem.Create().SetName("b").SaveX(t3)
+	assert.Equal(t, uint64(3), b.TenantID)
+	// the ignored tenant keeps the tenant of the data
+	c := client.TenantItem.Create().SetName("c").SaveX(tenantctx.WithIgnoreTenant(ctx))
+	assert.Equal(t, entenum.TenantDefaultId, c.TenantID)
+
+	// the updates and deletes of other tenants affect nothing
+	n, err := client.TenantItem.Update().SetName("x").Save(t3)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "a", client.TenantItem.GetX(t2, a.ID).Name)
+	_, err = client.TenantItem.UpdateOneID(a.ID).SetName("x").Save(t3)
+	assert.True(t, ent.IsNotFound(err))
+	assert.True(t, ent.IsNotFound(client.TenantItem.DeleteOneID(a.ID).Exec(t3)))
+	n, err = client.TenantItem.Delete().Where(tenantitem.Name("a")).Exec(t3)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	assert.Nil(t, client.TenantItem.DeleteOneID(a.ID).Exec(t2))
+	assert.Equal(t, 2, client.TenantItem.Query().CountX(tenantctx.AdminCtx(ctx)))
+}
+
+func TestTenantMixinInterceptors(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := tenantctx.WithIgnoreTenant(context.Background())
+	client.TenantItem.Create().SetName("a").SetTenantID(2).SaveX(ctx)
+	client.TenantItem.Create().SetName("b").SetTenantID(3).SaveX(ctx)
+
+	names := func(ctx context.Context) []string {
+		return client.TenantItem.Query().Order(ent.Asc(tenantitem.FieldName)).Select(tenantitem.FieldName).StringsX(ctx)
+	}
+	assert.Equal(t, []string{"a"}, names(tenantctx.WithTenantId(context.Background(), 2)))
+	assert.Equal(t, []string{"b"}, names(tenantctx.WithTenantIdCtx(context.Background(), "3")))
+	assert.Equal(t, []string{"a", "b"}, names(tenantctx.AdminCtx(context.Background())))
+	assert.Equal(t, []string{"a", "b"}, names(ctx))
+	assert.Equal(t, []string{"b"}, names(tenantctx.WithoutIgnoreTenant(tenantctx.WithTenantId(ctx, 3))))
+
+	// the tenant admin propagated by AdminCtx over gRPC
+	admin := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		string(tenantctx.TenantAdmin), "allow", enum.TenantIdCtxKey, "3"))
+	assert.Equal(t, []string{"a", "b"}, names(admin))
+	assert.Equal(t, 2, client.TenantItem.Query().CountX(admin))
+}
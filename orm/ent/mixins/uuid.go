@@ -21,6 +21,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/mixin"
 	"github.com/gofrs/uuid/v5"
+	"mingyang.com/admin-common/utils/idgen"
 
 	uuid2 "mingyang.com/admin-common/utils/uuidx"
 )
@@ -28,11 +29,18 @@ import (
 // UUIDMixin is the mixin with uuid v7 field which is used for universal unique.
 type UUIDMixin struct {
 	mixin.Schema
+	// Generator sets the ID on create if it is not set, uuidx.NewUUID is the default if it is nil.
+	Generator idgen.IDGenerator[uuid.UUID]
 }
 
-func (UUIDMixin) Fields() []ent.Field {
+func (m UUIDMixin) Fields() []ent.Field {
+	id := field.UUID("id", uuid.UUID{}).Comment("UUID")
+	if m.Generator == nil {
+		id.Default(uuid2.NewUUID)
+	}
+
 	return []ent.Field{
-		field.UUID("id", uuid.UUID{}).Default(uuid2.NewUUID).Comment("UUID"),
+		id,
 		field.Time("created_at").
 			Immutable().
 			Default(time.Now).
@@ -43,3 +51,8 @@ func (UUIDMixin) Fields() []ent.Field {
 			Comment("Update Time | 修改日期"),
 	}
 }
+
+// Hooks of the UUIDMixin.
+func (m UUIDMixin) Hooks() []ent.Hook {
+	return idGeneratorHooks(m.Generator)
+}
@@ -0,0 +1,154 @@
+package mixins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/utils/errcode"
+)
+
+// VersionFieldName is the version field name of VersionMixin.
+const VersionFieldName = "version"
+
+// VersionConflictError is returned when the data has been modified by others after it was read.
+// It is errcode.ErrVersionConflict, so it is converted to codes.Aborted and the i18n key common.versionConflict.
+type VersionConflictError struct {
+	Entity   string
+	EntityId string
+}
+
+func (e *VersionConflictError) Error() string {
+	if e.EntityId == "" {
+		return fmt.Sprintf("%s has been modified by others", e.Entity)
+	}
+	return fmt.Sprintf("%s %s has been modified by others", e.Entity, e.EntityId)
+}
+
+// GRPCStatus returns the status of errcode.ErrVersionConflict.
+func (e *VersionConflictError) GRPCStatus() *status.Status {
+	return status.Convert(errcode.ErrVersionConflict)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == errcode.ErrVersionConflict
+}
+
+// IsVersionConflict returns true if the error is the optimistic lock conflict.
+func IsVersionConflict(err error) bool {
+	return errors.Is(err, errcode.ErrVersionConflict)
+}
+
+// VersionMixin for embedding the optimistic lock version in different schemas.
+//
+// Set the version which was read to the update, the update only succeeds if the version is not changed,
+// otherwise VersionConflictError is returned:
+//
+//	client.User.UpdateOneID(id).SetName(name).SetVersion(req.Version).Save(ctx)
+//
+// The version is increased by every update, and the update without version is not checked.
+// The Update with version returns VersionConflictError if it matches data but updates none of them.
+type VersionMixin struct {
+	mixin.Schema
+}
+
+// Fields for all schemas that embed VersionMixin.
+func (VersionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int(VersionFieldName).
+			Default(0).
+			Comment("Version | 版本号"),
+	}
+}
+
+// Hooks of the VersionMixin.
+func (VersionMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				if !m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+					return next.Mutate(ctx, m)
+				}
+
+				v, ok := m.Field(VersionFieldName)
+				if !ok {
+					if err := m.AddField(VersionFieldName, 1); err != nil {
+						return nil, err
+					}
+					return next.Mutate(ctx, m)
+				}
+
+				expected, ok := v.(int)
+				if !ok {
+					return nil, fmt.Errorf("unexpected version type %T", v)
+				}
+				p, ok := m.(predicateAppender)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+
+				// the data matched by the other predicates, to tell the conflict from no data of Update
+				var ids []any
+				if m.Op().Is(ent.OpUpdate) {
+					var err error
+					if ids, err = mutationIDs(ctx, m); err != nil {
+						return nil, err
+					}
+				}
+
+				p.WhereP(sql.FieldEQ(VersionFieldName, expected))
+				if err := m.SetField(VersionFieldName, expected+1); err != nil {
+					return nil, err
+				}
+
+				value, err := next.Mutate(ctx, m)
+				if m.Op().Is(ent.OpUpdate) {
+					if err == nil && len(ids) > 0 {
+						if n, _ := value.(int); n == 0 {
+							return value, &VersionConflictError{Entity: m.Type()}
+						}
+					}
+					return value, err
+				}
+
+				// UpdateOne returns the not found error if no data matches
+				if err != nil {
+					if id, changed := versionChanged(ctx, m, expected); changed {
+						return value, &VersionConflictError{Entity: m.Type(), EntityId: id}
+					}
+				}
+				return value, err
+			})
+		},
+	}
+}
+
+// versionChanged returns the ID and true if the data of UpdateOne exists and its version is not the expected one.
+func versionChanged(ctx context.Context, m ent.Mutation, expected int) (string, bool) {
+	ids, err := mutationIDs(historyLoadCtx(ctx), m)
+	if err != nil || len(ids) != 1 {
+		return "", false
+	}
+
+	id := fmt.Sprint(ids[0])
+	entities, err := loadEntities(ctx, m, ids)
+	if err != nil {
+		return id, false
+	}
+
+	data, ok := entities[id]
+	if !ok {
+		return id, false
+	}
+	// the zero version is omitted in json
+	version, ok := data[VersionFieldName]
+	if !ok {
+		version = 0
+	}
+	return id, fmt.Sprint(version) != fmt.Sprint(expected)
+}
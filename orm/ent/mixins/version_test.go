@@ -0,0 +1,70 @@
+package mixins_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent"
+	"mingyang.com/admin-common/orm/ent/internal/testent/ent/versionitem"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+func TestVersionMixinUpdateOne(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	a := client.VersionItem.Create().SetName("a").SaveX(ctx)
+	assert.Equal(t, 0, a.Version)
+
+	// the version which was read is checked and increased
+	a = client.VersionItem.UpdateOne(a).SetName("b").SetVersion(0).SaveX(ctx)
+	assert.Equal(t, 1, a.Version)
+	assert.Equal(t, "b", a.Name)
+
+	// the stale version conflicts
+	_, err := client.VersionItem.UpdateOneID(a.ID).SetName("c").SetVersion(0).Save(ctx)
+	assert.True(t, mixins.IsVersionConflict(err))
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, "b", client.VersionItem.GetX(ctx, a.ID).Name)
+
+	// the missing data is not found instead of the conflict
+	_, err = client.VersionItem.UpdateOneID(a.ID+1).SetName("c").SetVersion(1).Save(ctx)
+	assert.True(t, ent.IsNotFound(err))
+	assert.False(t, mixins.IsVersionConflict(err))
+
+	// the update without version is not checked
+	a = client.VersionItem.UpdateOneID(a.ID).SetName("d").SaveX(ctx)
+	assert.Equal(t, 2, a.Version)
+}
+
+func TestVersionMixinUpdate(t *testing.T) {
+	client := openClient(t)
+	defer client.Close()
+	ctx := context.Background()
+
+	a := client.VersionItem.Create().SetName("a").SaveX(ctx)
+
+	n, err := client.VersionItem.Update().Where(versionitem.ID(a.ID)).SetName("b").SetVersion(0).Save(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, client.VersionItem.GetX(ctx, a.ID).Version)
+
+	// the stale version conflicts
+	n, err = client.VersionItem.Update().Where(versionitem.ID(a.ID)).SetName("c").SetVersion(0).Save(ctx)
+	assert.True(t, mixins.IsVersionConflict(err))
+	assert.Equal(t, 0, n)
+
+	// no data matches the other predicates
+	n, err = client.VersionItem.Update().Where(versionitem.Name("missing")).SetName("c").SetVersion(1).Save(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	n, err = client.VersionItem.Update().Where(versionitem.Name("b")).SetName("c").Save(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 2, client.VersionItem.GetX(ctx, a.ID).Version)
+}
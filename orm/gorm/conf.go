@@ -15,27 +15,32 @@
 package gorm
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
 // Conf is the configuration structure for GORM.
 type Conf struct {
-	Type        string `json:",default=mysql,options=[mysql,postgres]"` // type of database: mysql, postgres
-	Host        string `json:",default=localhost"`                      // address
-	Port        int    `json:",default=3306"`                           // port
-	Config      string `json:",optional"`                               // extra config such as charset=utf8mb4&parseTime=True
-	DBName      string `json:",default=simple_admin"`                   // database name
-	Username    string `json:",default=root"`                           // username
-	Password    string `json:",optional"`                               // password
-	MaxIdleConn int    `json:",default=10"`                             // the maximum number of connections in the idle connection pool
-	MaxOpenConn int    `json:",default=100"`                            // the maximum number of open connections to the database
-	LogMode     string `json:",default=error"`                          // open gorm's global logger
+	Type          string `json:",default=mysql,options=[mysql,postgres,sqlite3]"` // type of database: mysql, postgres, sqlite3
+	Host          string `json:",default=localhost"`                              // address
+	Port          int    `json:",default=3306"`                                   // port
+	Config        string `json:",optional"`                                       // extra config such as charset=utf8mb4&parseTime=True
+	DBName        string `json:",default=simple_admin"`                           // database name
+	DBPath        string `json:",optional"`                                       // the database file path of sqlite3
+	Username      string `json:",default=root"`                                   // username
+	Password      string `json:",optional"`                                       // password
+	MaxIdleConn   int    `json:",default=10"`                                     // the maximum number of connections in the idle connection pool
+	MaxOpenConn   int    `json:",default=100"`                                    // the maximum number of open connections to the database
+	LogMode       string `json:",default=error"`                                  // open gorm's global logger
+	SlowThreshold int    `json:",default=1000"`                                   // the milliseconds of the slow sql logged at warn level, 0 disables it
+	LogParams     bool   `json:",optional"`                                       // log the sql with the param values, which may be sensitive
 }
 
 // MysqlDSN returns the MySQL DSN link from the configuration.
@@ -49,14 +54,23 @@ func (g Conf) PostgreSqlDSN() string {
 		g.DBName, g.Port, g.Config)
 }
 
+// SqliteDSN returns the SQLite DSN link from the configuration, the same as config.DatabaseConf.
+func (g Conf) SqliteDSN() string {
+	return fmt.Sprintf("file:%s?_busy_timeout=100000&_fk=1%s", g.DBPath, g.Config)
+}
+
+// NewGORM returns the gorm DB of the database type, the empty type is mysql and "pgsql" is kept as
+// an alias of postgres.
 func (g Conf) NewGORM() (*gorm.DB, error) {
 	switch g.Type {
-	case "mysql":
+	case "mysql", "":
 		return MysqlClient(g)
-	case "pgsql":
+	case "postgres", "pgsql":
 		return PgSqlClient(g)
+	case "sqlite3":
+		return SqliteClient(g)
 	default:
-		return MysqlClient(g)
+		return nil, fmt.Errorf("unsupported database type %q", g.Type)
 	}
 }
 
@@ -70,21 +84,7 @@ func MysqlClient(c Conf) (*gorm.DB, error) {
 		SkipInitializeWithVersion: false, // autoconfiguration based on currently MySQL version
 	}
 
-	if db, err := gorm.Open(mysql.New(mysqlConfig), &gorm.Config{
-		Logger: logger.New(writer{}, logger.Config{
-			SlowThreshold:             1 * time.Second,
-			Colorful:                  true,
-			IgnoreRecordNotFoundError: false,
-			LogLevel:                  getLevel(c.LogMode),
-		}),
-	}); err != nil {
-		return nil, err
-	} else {
-		sqlDB, _ := db.DB()
-		sqlDB.SetMaxIdleConns(c.MaxIdleConn)
-		sqlDB.SetMaxOpenConns(c.MaxOpenConn)
-		return db, nil
-	}
+	return open(c, mysql.New(mysqlConfig))
 }
 
 func PgSqlClient(c Conf) (*gorm.DB, error) {
@@ -93,21 +93,37 @@ func PgSqlClient(c Conf) (*gorm.DB, error) {
 		PreferSimpleProtocol: false, // disables implicit prepared statement usage
 	}
 
-	if db, err := gorm.Open(postgres.New(pgsqlConfig), &gorm.Config{
-		Logger: logger.New(writer{}, logger.Config{
-			SlowThreshold:             1 * time.Second,
-			Colorful:                  true,
-			IgnoreRecordNotFoundError: false,
-			LogLevel:                  getLevel(c.LogMode),
+	return open(c, postgres.New(pgsqlConfig))
+}
+
+func SqliteClient(c Conf) (*gorm.DB, error) {
+	if c.DBPath == "" {
+		return nil, errors.New("the database file path cannot be empty")
+	}
+
+	return open(c, sqlite.Open(c.SqliteDSN()))
+}
+
+// open returns the gorm DB with the logger and the pool settings.
+func open(c Conf, dialector gorm.Dialector) (*gorm.DB, error) {
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: NewLogger(LoggerConf{
+			SlowThreshold:        time.Duration(c.SlowThreshold) * time.Millisecond,
+			LogLevel:             getLevel(c.LogMode),
+			ParameterizedQueries: !c.LogParams,
 		}),
-	}); err != nil {
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
 		return nil, err
-	} else {
-		sqlDB, _ := db.DB()
-		sqlDB.SetMaxIdleConns(c.MaxIdleConn)
-		sqlDB.SetMaxOpenConns(c.MaxOpenConn)
-		return db, nil
 	}
+	sqlDB.SetMaxIdleConns(c.MaxIdleConn)
+	sqlDB.SetMaxOpenConns(c.MaxOpenConn)
+	return db, nil
 }
 
 // getLevel returns the gorm level from the level in go zero.
@@ -0,0 +1,170 @@
+package gorm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/core/logx/logtest"
+	"google.golang.org/grpc/metadata"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"mingyang.com/admin-common/orm/ent/entctx/datapermctx"
+	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entenum"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+type testItem struct {
+	Id   uint64 `gorm:"primaryKey"`
+	Name string
+	TenantModel
+	DepartmentModel
+	SoftDeleteModel
+}
+
+type testDoc struct {
+	Id           uint64 `gorm:"primaryKey"`
+	Name         string
+	DepartmentId uint64
+}
+
+func (testDoc) DataPerm() mixins.DataPermMixin {
+	return mixins.DataPermMixin{}
+}
+
+func openTestDB(t *testing.T) *gorm.DB {
+	db, err := Conf{Type: "sqlite3", DBPath: filepath.Join(t.TempDir(), "test.db"), MaxOpenConn: 1,
+		LogMode: "silent"}.NewGORM()
+	assert.Nil(t, err)
+	assert.Nil(t, db.Use(Isolation{}))
+	assert.Nil(t, db.AutoMigrate(&testItem{}, &testDoc{}))
+	return db
+}
+
+func itemNames(t *testing.T, db *gorm.DB) []string {
+	var names []string
+	assert.Nil(t, db.Order("name").Pluck("name", &names).Error)
+	return names
+}
+
+func TestNewGORM(t *testing.T) {
+	_, err := Conf{Type: "oracle"}.NewGORM()
+	assert.NotNil(t, err)
+	_, err = Conf{Type: "sqlite3"}.NewGORM()
+	assert.NotNil(t, err)
+
+	db, err := Conf{Type: "sqlite3", DBPath: filepath.Join(t.TempDir(), "test.db"), MaxOpenConn: 2}.NewGORM()
+	assert.Nil(t, err)
+	sqlDB, err := db.DB()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, sqlDB.Stats().MaxOpenConnections)
+	assert.Nil(t, sqlDB.Close())
+}
+
+func TestIsolation(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	t2 := deptctx.WithDepartmentIDs(tenantctx.WithTenantId(ctx, 2), 10)
+	t3 := deptctx.WithDepartmentIDs(tenantctx.WithTenantId(ctx, 3), 10, 20)
+
+	// the tenant in context overwrites the tenant of the data
+	assert.Nil(t, db.WithContext(t2).Create(&[]testItem{{Name: "a", DepartmentModel: DepartmentModel{10}},
+		{Name: "b", TenantModel: TenantModel{3}, DepartmentModel: DepartmentModel{10}}}).Error)
+	assert.Nil(t, db.WithContext(t3).Create(&testItem{Name: "b", DepartmentModel: DepartmentModel{20}}).Error)
+	assert.Nil(t, db.WithContext(tenantctx.WithIgnoreTenant(ctx)).Create(&testItem{Name: "c"}).Error)
+
+	all := deptctx.SkipDepartmentFilter(tenantctx.AdminCtx(ctx))
+	var items []testItem
+	assert.Nil(t, db.WithContext(all).Order("id").Find(&items).Error)
+	assert.Len(t, items, 4)
+	assert.Equal(t, []uint64{2, 2, 3, entenum.TenantDefaultId},
+		[]uint64{items[0].TenantId, items[1].TenantId, items[2].TenantId, items[3].TenantId})
+
+	assert.Equal(t, []string{"a", "b"}, itemNames(t, db.WithContext(t2).Model(&testItem{})))
+	assert.Equal(t, []string{"b"}, itemNames(t, db.WithContext(t3).Model(&testItem{})))
+	// the OR conditions are grouped before the tenant filter
+	assert.Equal(t, []string{"a", "b"}, itemNames(t, db.WithContext(t2).Model(&testItem{}).
+		Where("name = ?", "a").Or("name = ?", "b")))
+	// the department filter
+	assert.Equal(t, []string{"b"}, itemNames(t, db.WithContext(tenantctx.WithTenantId(
+		deptctx.WithDepartmentIDs(ctx, 20), 3)).Model(&testItem{})))
+	assert.Empty(t, itemNames(t, db.WithContext(tenantctx.WithTenantId(ctx, 2)).Model(&testItem{})))
+	// the tenant admin propagated by AdminCtx over gRPC
+	admin := metadata.NewIncomingContext(t3, metadata.Pairs(string(tenantctx.TenantAdmin), "allow"))
+	assert.Equal(t, []string{"a", "b", "b"}, itemNames(t, db.WithContext(admin).Model(&testItem{})))
+
+	// the updates and deletes of other tenants affect nothing
+	assert.Equal(t, int64(0), db.WithContext(t3).Model(&testItem{}).Where("name = ?", "a").
+		Update("name", "x").RowsAffected)
+	assert.Equal(t, int64(0), db.WithContext(t3).Where("name = ?", "a").Delete(&testItem{}).RowsAffected)
+
+	// soft delete
+	assert.Equal(t, int64(1), db.WithContext(t2).Where("name = ?", "a").Delete(&testItem{}).RowsAffected)
+	assert.Equal(t, []string{"b"}, itemNames(t, db.WithContext(t2).Model(&testItem{})))
+	assert.Equal(t, []string{"a", "b"}, itemNames(t, db.WithContext(mixins.IncludeDeleted(t2)).Model(&testItem{})))
+	assert.Equal(t, int64(1), db.WithContext(mixins.WithHardDelete(t2)).Where("name = ?", "a").
+		Delete(&testItem{}).RowsAffected)
+	assert.Equal(t, []string{"b"}, itemNames(t, db.WithContext(mixins.IncludeDeleted(t2)).Model(&testItem{})))
+
+	// the updates and deletes without conditions are rejected before the isolation conditions are added
+	assert.ErrorIs(t, db.WithContext(t2).Model(&testItem{}).Update("name", "x").Error, gorm.ErrMissingWhereClause)
+	assert.ErrorIs(t, db.WithContext(t2).Delete(&testItem{}).Error, gorm.ErrMissingWhereClause)
+	assert.Equal(t, []string{"b"}, itemNames(t, db.WithContext(t2).Model(&testItem{})))
+	var item testItem
+	assert.Nil(t, db.WithContext(t2).Take(&item).Error)
+	assert.Equal(t, int64(1), db.WithContext(t2).Model(&item).Update("name", "c").RowsAffected)
+	assert.Equal(t, int64(1), db.WithContext(t2).Delete(&item).RowsAffected)
+	assert.Equal(t, int64(1), db.WithContext(t3).Session(&gorm.Session{AllowGlobalUpdate: true}).
+		Model(&testItem{}).Update("name", "x").RowsAffected)
+
+	// the scopes for the tables without model
+	var count int64
+	assert.Nil(t, db.WithContext(t3).Table("test_items").Scopes(TenantScope).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []string{"x"}, itemNames(t, db.WithContext(t3).Model(&testItem{})))
+}
+
+func TestDataPerm(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	assert.Nil(t, db.Create(&[]testDoc{{Name: "a", DepartmentId: 10}, {Name: "b", DepartmentId: 20}}).Error)
+
+	assert.Equal(t, []string{"a", "b"}, itemNames(t, db.WithContext(datapermctx.WithScopeContext(ctx,
+		entenum.DataPermAllStr)).Model(&testDoc{})))
+	assert.Equal(t, []string{"b"}, itemNames(t, db.WithContext(datapermctx.WithCustomDeptContext(
+		datapermctx.WithScopeContext(ctx, entenum.DataPermCustomDeptStr), "20")).Model(&testDoc{})))
+
+	// the data permission is required
+	var names []string
+	assert.NotNil(t, db.WithContext(ctx).Model(&testDoc{}).Pluck("name", &names).Error)
+	assert.Equal(t, []string{"a", "b"}, itemNames(t, db.WithContext(datapermctx.WithIgnoreDataPerm(ctx)).
+		Model(&testDoc{})))
+}
+
+func TestLogger(t *testing.T) {
+	buf := logtest.NewCollector(t)
+	ctx := context.Background()
+	l := NewLogger(LoggerConf{SlowThreshold: time.Millisecond, LogLevel: logger.Warn, ParameterizedQueries: true})
+
+	l.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+	assert.Empty(t, buf.String())
+	l.Trace(ctx, time.Now().Add(-time.Second), func() (string, int64) { return "SELECT 2", 1 }, nil)
+	assert.Contains(t, buf.String(), `"level":"slow"`)
+	assert.Contains(t, buf.String(), "SELECT 2")
+
+	buf.Reset()
+	l.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 3", 0 }, gorm.ErrInvalidData)
+	assert.Contains(t, buf.String(), `"level":"error"`)
+
+	buf.Reset()
+	l.LogMode(logger.Info).Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 4", 0 }, nil)
+	assert.Contains(t, buf.String(), "SELECT 4")
+
+	sql, params := l.ParamsFilter(ctx, "SELECT ?", 1)
+	assert.Equal(t, "SELECT ?", sql)
+	assert.Nil(t, params)
+}
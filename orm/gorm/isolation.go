@@ -0,0 +1,286 @@
+package gorm
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"mingyang.com/admin-common/orm/ent/entctx/deptctx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+	"mingyang.com/admin-common/orm/ent/entenum"
+	"mingyang.com/admin-common/orm/ent/mixins"
+)
+
+// TenantModel is embedded in the gorm models isolated by tenant like mixins.TenantMixin.
+type TenantModel struct {
+	TenantId uint64 `gorm:"column:tenant_id;not null;index" json:"tenantId"`
+}
+
+func (TenantModel) tenantModel() {}
+
+// DepartmentModel is embedded in the gorm models filtered by department like mixins.DepartmentMixin.
+type DepartmentModel struct {
+	DepartmentId uint64 `gorm:"column:department_id" json:"departmentId"`
+}
+
+func (DepartmentModel) departmentModel() {}
+
+// SoftDeleteModel is embedded in the gorm models soft-deleted like mixins.SoftDeleteMixin.
+type SoftDeleteModel struct {
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deletedAt"`
+}
+
+// DataPermModel is implemented by the gorm models filtered by the data permission like mixins.DataPermMixin,
+// the returned mixin configures the department and creator fields.
+type DataPermModel interface {
+	DataPerm() mixins.DataPermMixin
+}
+
+type tenantModel interface {
+	tenantModel()
+}
+
+type departmentModel interface {
+	departmentModel()
+}
+
+// Isolation is the gorm plugin applying the context of the ent mixins to the gorm models, so the gorm-based
+// services get the same isolation:
+//
+//	TenantModel      filters and sets tenant_id by tenantctx, skipped by the tenant admin and WithIgnoreTenant
+//	DepartmentModel  filters department_id by deptctx.GetDepartmentIDsFromCtx
+//	DataPermModel    filters by the data scope in context
+//	SoftDeleteModel  includes the soft-deleted data by mixins.IncludeDeleted, and deletes the data by
+//	                 mixins.WithHardDelete
+//
+// Use it by db.Use(Isolation{}). The raw sql is not filtered, use the scopes such as TenantScope instead.
+// The updates and deletes without conditions still fail with gorm.ErrMissingWhereClause unless AllowGlobalUpdate.
+type Isolation struct {
+	// AllowWithoutDepartment returns all data of DepartmentModel when there is no department in context,
+	// otherwise no data is returned.
+	AllowWithoutDepartment bool
+}
+
+// Name returns the name of the plugin.
+func (Isolation) Name() string {
+	return "admin-common:isolation"
+}
+
+// Initialize registers the callbacks of the plugin.
+func (p Isolation) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("admin-common:isolation_create", p.create); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("admin-common:isolation_query", p.filter); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("admin-common:isolation_row", p.filter); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("admin-common:isolation_update", p.update); err != nil {
+		return err
+	}
+	return callbacks.Delete().Before("gorm:delete").Register("admin-common:isolation_delete", p.delete)
+}
+
+// create sets the tenant in context like mixins.TenantMixin, the default tenant is set if it is skipped.
+func (p Isolation) create(db *gorm.DB) {
+	if _, ok := model(db).(tenantModel); !ok {
+		return
+	}
+
+	if skipTenantFilter(db) {
+		if field := db.Statement.Schema.LookUpField(mixins.TenantFieldName); field != nil {
+			setFieldIfZero(db, field, entenum.TenantDefaultId)
+		}
+		return
+	}
+	if field := db.Statement.Schema.LookUpField(mixins.TenantFieldName); field != nil {
+		setField(db, field, tenantctx.GetTenantIDFromCtx(db.Statement.Context))
+	}
+}
+
+// filter adds the conditions of the models to the queries and updates.
+func (p Isolation) filter(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if mixins.IsIncludeDeleted(db.Statement.Context) {
+		db.Statement.Unscoped = true
+	}
+
+	m := model(db)
+	if _, ok := m.(tenantModel); ok {
+		TenantScope(db)
+	}
+	if _, ok := m.(departmentModel); ok {
+		DepartmentScope(p.AllowWithoutDepartment)(db)
+	}
+	if d, ok := m.(DataPermModel); ok {
+		DataPermScope(d.DataPerm())(db)
+	}
+}
+
+// update filters the updates, the updates without conditions are rejected before the isolation conditions
+// are added.
+func (p Isolation) update(db *gorm.DB) {
+	checkMissingWhere(db)
+	p.filter(db)
+}
+
+// delete filters the deletes like the updates, and removes the data for mixins.WithHardDelete.
+func (p Isolation) delete(db *gorm.DB) {
+	checkMissingWhere(db)
+	p.filter(db)
+	if mixins.IsHardDelete(db.Statement.Context) {
+		db.Statement.Unscoped = true
+	}
+}
+
+// TenantScope filters the data by the tenant in context like mixins.TenantMixin.
+func TenantScope(db *gorm.DB) *gorm.DB {
+	if skipTenantFilter(db) {
+		return db
+	}
+
+	addWhere(db, clause.Eq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: mixins.TenantFieldName},
+		Value:  tenantctx.GetTenantIDFromCtx(db.Statement.Context),
+	})
+	return db
+}
+
+// DepartmentScope filters the data by the departments in context like mixins.DepartmentMixin.
+func DepartmentScope(allowWithoutDepartment bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if deptctx.GetSkipDepartmentFilterFromCtx(db.Statement.Context) {
+			return db
+		}
+
+		ids, err := deptctx.GetDepartmentIDsFromCtx(db.Statement.Context)
+		if err != nil {
+			if !allowWithoutDepartment {
+				addWhere(db, clause.Expr{SQL: "1 = 0"})
+			}
+			return db
+		}
+
+		// an empty department set returns no data
+		values := make([]any, 0, len(ids))
+		for _, v := range ids {
+			values = append(values, v)
+		}
+		addWhere(db, clause.IN{
+			Column: clause.Column{Table: clause.CurrentTable, Name: mixins.DepartmentFieldName},
+			Values: values,
+		})
+		return db
+	}
+}
+
+// DataPermScope filters the data by the data scope in context like mixins.DataPermMixin.
+func DataPermScope(d mixins.DataPermMixin) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		field, values, err := d.Condition(db.Statement.Context)
+		if err != nil {
+			_ = db.AddError(err)
+			return db
+		}
+		if field == "" {
+			return db
+		}
+
+		addWhere(db, clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: field}, Values: values})
+		return db
+	}
+}
+
+// skipTenantFilter returns true when the tenant filter is ignored or the context has tenant admin authority.
+func skipTenantFilter(db *gorm.DB) bool {
+	return tenantctx.GetIgnoreTenantFromCtx(db.Statement.Context) || tenantctx.GetTenantAdminCtx(db.Statement.Context)
+}
+
+// checkMissingWhere adds gorm.ErrMissingWhereClause like gorm if the update or delete has neither the conditions
+// nor the primary keys, which is checked by gorm after the isolation conditions are added and always passes.
+func checkMissingWhere(db *gorm.DB) {
+	if db.Error != nil || db.AllowGlobalUpdate {
+		return
+	}
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) > 0 {
+			return
+		}
+	}
+
+	// the primary keys of the model or the deleted data are added as the conditions by gorm
+	if db.Statement.Schema != nil {
+		for _, v := range []any{db.Statement.Dest, db.Statement.Model} {
+			rv := reflect.Indirect(reflect.ValueOf(v))
+			switch rv.Kind() {
+			case reflect.Struct, reflect.Slice, reflect.Array:
+				if _, values := schema.GetIdentityFieldValuesMap(db.Statement.Context, rv,
+					db.Statement.Schema.PrimaryFields); len(values) > 0 {
+					return
+				}
+			}
+		}
+	}
+	_ = db.AddError(gorm.ErrMissingWhereClause)
+}
+
+// addWhere adds the conditions with AND, the existing OR conditions are grouped first like the gorm soft delete.
+func addWhere(db *gorm.DB, exprs ...clause.Expression) {
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) >= 1 {
+			for _, expr := range where.Exprs {
+				if orCond, ok := expr.(clause.OrConditions); ok && len(orCond.Exprs) == 1 {
+					where.Exprs = []clause.Expression{clause.And(where.Exprs...)}
+					c.Expression = where
+					db.Statement.Clauses["WHERE"] = c
+					break
+				}
+			}
+		}
+	}
+	db.Statement.AddClause(clause.Where{Exprs: exprs})
+}
+
+// model returns the new model of the statement schema, nil if the schema is unknown.
+func model(db *gorm.DB) any {
+	if db.Statement.Schema == nil {
+		return nil
+	}
+	return reflect.New(db.Statement.Schema.ModelType).Interface()
+}
+
+// setField sets the field of the created struct or structs.
+func setField(db *gorm.DB, field *schema.Field, value any) {
+	eachCreated(db, func(rv reflect.Value) {
+		_ = db.AddError(field.Set(db.Statement.Context, rv, value))
+	})
+}
+
+// setFieldIfZero sets the field of the created struct or structs if it is zero.
+func setFieldIfZero(db *gorm.DB, field *schema.Field, value any) {
+	eachCreated(db, func(rv reflect.Value) {
+		if _, zero := field.ValueOf(db.Statement.Context, rv); zero {
+			_ = db.AddError(field.Set(db.Statement.Context, rv, value))
+		}
+	})
+}
+
+func eachCreated(db *gorm.DB, fn func(reflect.Value)) {
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				fn(elem)
+			}
+		}
+	case reflect.Struct:
+		fn(rv)
+	}
+}
@@ -0,0 +1,103 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// LoggerConf is the configuration of Logger.
+type LoggerConf struct {
+	// SlowThreshold is the duration of the slow sql logged at warn level, 0 disables the slow log
+	SlowThreshold time.Duration
+	// LogLevel is the gorm log level
+	LogLevel logger.LogLevel
+	// IgnoreRecordNotFoundError skips the gorm.ErrRecordNotFound errors
+	IgnoreRecordNotFoundError bool
+	// ParameterizedQueries logs the sql with the placeholders instead of the param values
+	ParameterizedQueries bool
+}
+
+// Logger is the gorm logger writing to logx by level. The gorm warn level is written as logx slow,
+// and the sql is logged with the duration, affected rows and the source line out of gorm.
+type Logger struct {
+	conf LoggerConf
+}
+
+var (
+	_ logger.Interface  = (*Logger)(nil)
+	_ gorm.ParamsFilter = (*Logger)(nil)
+)
+
+// NewLogger returns the gorm logger writing to logx.
+func NewLogger(c LoggerConf) *Logger {
+	return &Logger{conf: c}
+}
+
+// LogMode returns the logger with the level.
+func (l *Logger) LogMode(level logger.LogLevel) logger.Interface {
+	c := l.conf
+	c.LogLevel = level
+	return NewLogger(c)
+}
+
+func (l *Logger) Info(ctx context.Context, msg string, data ...any) {
+	if l.conf.LogLevel >= logger.Info {
+		logx.WithContext(ctx).Infow(fmt.Sprintf(msg, data...), logx.Field("source", utils.FileWithLineNum()))
+	}
+}
+
+func (l *Logger) Warn(ctx context.Context, msg string, data ...any) {
+	if l.conf.LogLevel >= logger.Warn {
+		logx.WithContext(ctx).Sloww(fmt.Sprintf(msg, data...), logx.Field("source", utils.FileWithLineNum()))
+	}
+}
+
+func (l *Logger) Error(ctx context.Context, msg string, data ...any) {
+	if l.conf.LogLevel >= logger.Error {
+		logx.WithContext(ctx).Errorw(fmt.Sprintf(msg, data...), logx.Field("source", utils.FileWithLineNum()))
+	}
+}
+
+// Trace logs the failed sql at error level, the slow sql at warn level and all sql at info level.
+func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.conf.LogLevel <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	fields := func() []logx.LogField {
+		sql, rows := fc()
+		return []logx.LogField{
+			logx.Field("sql", sql),
+			logx.Field("rows", rows),
+			logx.Field("source", utils.FileWithLineNum()),
+		}
+	}
+
+	switch {
+	case err != nil && l.conf.LogLevel >= logger.Error &&
+		(!errors.Is(err, gorm.ErrRecordNotFound) || !l.conf.IgnoreRecordNotFoundError):
+		logx.WithContext(ctx).WithDuration(elapsed).Errorw("sql error",
+			append(fields(), logx.Field("detail", err.Error()))...)
+	case l.conf.SlowThreshold > 0 && elapsed > l.conf.SlowThreshold && l.conf.LogLevel >= logger.Warn:
+		logx.WithContext(ctx).WithDuration(elapsed).Sloww("slow sql",
+			append(fields(), logx.Field("slowThreshold", l.conf.SlowThreshold.String()))...)
+	case l.conf.LogLevel >= logger.Info:
+		logx.WithContext(ctx).WithDuration(elapsed).Infow("sql", fields()...)
+	}
+}
+
+// ParamsFilter removes the params of the logged sql if ParameterizedQueries is true.
+func (l *Logger) ParamsFilter(_ context.Context, sql string, params ...any) (string, []any) {
+	if l.conf.ParameterizedQueries {
+		return sql, nil
+	}
+	return sql, params
+}
@@ -1,29 +0,0 @@
-// Copyright 2023 The Ryan SU Authors (https://github.com/suyuan32). All Rights Reserved.
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//      http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
-package gorm
-
-import (
-	"gorm.io/gorm/logger"
-
-	"github.com/zeromicro/go-zero/core/logx"
-)
-
-type writer struct {
-	logger.Writer
-}
-
-func (l writer) Printf(message string, data ...any) {
-	logx.Errorf(message, data...)
-}
@@ -6,7 +6,6 @@ import (
 	"github.com/zeromicro/go-zero/core/discov"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/rest"
-	"github.com/zeromicro/go-zero/rest/httpx"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -142,12 +141,11 @@ func loadFromEtcd[T any](bc BootstrapConf) (T, configurator.Configurator[T]) {
 	return cfg, cc
 }
 
+// Health returns the route reporting the health checks registered by AddHealthCheck.
 func Health() rest.Route {
 	return rest.Route{
-		Method: http.MethodGet,
-		Path:   "/health",
-		Handler: func(w http.ResponseWriter, r *http.Request) {
-			httpx.OkJson(w, map[string]string{"status": "ok"})
-		},
+		Method:  http.MethodGet,
+		Path:    "/health",
+		Handler: healthHandler,
 	}
 }
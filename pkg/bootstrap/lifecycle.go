@@ -0,0 +1,129 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/proc"
+	"github.com/zeromicro/go-zero/rest/httpx"
+)
+
+// HealthCheckTimeout is the timeout of the health checks in Health.
+const HealthCheckTimeout = 3 * time.Second
+
+// HealthCheck checks a dependency of the service, such as the database.
+type HealthCheck func(ctx context.Context) error
+
+type namedCloser struct {
+	name   string
+	closer io.Closer
+}
+
+var (
+	lifecycleLock sync.Mutex
+	healthChecks  = map[string]HealthCheck{}
+	closers       []namedCloser
+	shutdownOnce  sync.Once
+)
+
+// AddHealthCheck registers the check reported by Health with the name.
+func AddHealthCheck(name string, check HealthCheck) {
+	lifecycleLock.Lock()
+	defer lifecycleLock.Unlock()
+
+	healthChecks[name] = check
+}
+
+// AddCloser registers the resource which is closed when the service shuts down, the resources are
+// closed in the reverse order of registration.
+func AddCloser(name string, closer io.Closer) {
+	lifecycleLock.Lock()
+	defer lifecycleLock.Unlock()
+
+	closers = append(closers, namedCloser{name: name, closer: closer})
+	shutdownOnce.Do(func() {
+		proc.AddShutdownListener(func() {
+			if err := Close(); err != nil {
+				logx.Errorw("failed to close resources on shutdown", logx.Field("detail", err.Error()))
+			}
+		})
+	})
+}
+
+// Close closes the resources registered by AddCloser in the reverse order, it is called on shutdown.
+func Close() error {
+	lifecycleLock.Lock()
+	list := closers
+	closers = nil
+	lifecycleLock.Unlock()
+
+	var errs []error
+	for i := len(list) - 1; i >= 0; i-- {
+		if err := list[i].closer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", list[i].name, err))
+			continue
+		}
+		logx.Infow("resource is closed", logx.Field("name", list[i].name))
+	}
+	return errors.Join(errs...)
+}
+
+// CheckHealth runs the health checks and returns the errors by name.
+func CheckHealth(ctx context.Context) map[string]string {
+	lifecycleLock.Lock()
+	checks := make(map[string]HealthCheck, len(healthChecks))
+	for k, v := range healthChecks {
+		checks[k] = v
+	}
+	lifecycleLock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		result = map[string]string{}
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := check(ctx); err != nil {
+				mu.Lock()
+				result[name] = err.Error()
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+// healthHandler serves the unauthenticated health endpoint, so the response only
+// names the failing checks and the errors, which may contain hosts or DSNs, are logged.
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	if errs := CheckHealth(r.Context()); len(errs) > 0 {
+		names := make([]string, 0, len(errs))
+		for name, detail := range errs {
+			logx.WithContext(r.Context()).Errorw("health check failed",
+				logx.Field("check", name), logx.Field("error", detail))
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		httpx.WriteJsonCtx(r.Context(), w, http.StatusServiceUnavailable, map[string]any{
+			"status": "error",
+			"checks": names,
+		})
+		return
+	}
+	httpx.OkJson(w, map[string]string{"status": "ok"})
+}
+
@@ -0,0 +1,29 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHandlerHidesErrors(t *testing.T) {
+	AddHealthCheck("database", func(context.Context) error {
+		return errors.New("dial tcp db.internal:3306: access denied for user 'root'")
+	})
+	defer func() {
+		lifecycleLock.Lock()
+		delete(healthChecks, "database")
+		lifecycleLock.Unlock()
+	}()
+
+	rec := httptest.NewRecorder()
+	healthHandler(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"status":"error","checks":["database"]}`, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "db.internal")
+}
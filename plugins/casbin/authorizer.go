@@ -0,0 +1,170 @@
+package casbin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/zeromicro/go-zero/core/errorx"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/auth"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/rolectx"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+// Authorizer 使用 Casbin 校验当前角色是否有权访问接口。
+//
+// subject 为 context 中的角色编码(rolectx.GetRoleIDFromCtx), 任一角色通过即放行;
+// HTTP 请求 object 为请求路径、action 为 HTTP 方法,
+// RPC 请求 object 为完整方法名 /pkg.Service/Method、action 为方法名。
+// 开启 WithTenantDomain 后按 (sub, dom, obj, act) 校验, dom 为当前租户 ID。
+type Authorizer struct {
+	enforcer    casbin.IEnforcer
+	withDomain  bool
+	adminBypass func(ctx context.Context) bool
+	skipMethods []string
+}
+
+// AuthorizerOption Authorizer 可选项
+type AuthorizerOption func(*Authorizer)
+
+// WithTenantDomain 按租户域校验, 模型的 request_definition 需为 r = sub, dom, obj, act
+func WithTenantDomain() AuthorizerOption {
+	return func(a *Authorizer) {
+		a.withDomain = true
+	}
+}
+
+// WithAdminBypass 设置超级管理员判断函数, 返回 true 时跳过校验, 为 nil 时关闭跳过。
+// 默认使用 tenantctx.GetTenantAdminCtx, 即 tenantctx.AdminCtx 设置并经 metadata 传递的管理员标记
+func WithAdminBypass(bypass func(ctx context.Context) bool) AuthorizerOption {
+	return func(a *Authorizer) {
+		a.adminBypass = bypass
+	}
+}
+
+// WithSkipMethods 设置不校验的 RPC 方法, 支持完整方法名与服务通配 /pkg.Service/*
+func WithSkipMethods(methods ...string) AuthorizerOption {
+	return func(a *Authorizer) {
+		a.skipMethods = append(a.skipMethods, methods...)
+	}
+}
+
+// NewAuthorizer 创建 Casbin 鉴权器
+func NewAuthorizer(enforcer casbin.IEnforcer, opts ...AuthorizerOption) *Authorizer {
+	a := &Authorizer{enforcer: enforcer, adminBypass: tenantctx.GetTenantAdminCtx}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Authorize 校验 context 中的角色是否有权对 obj 执行 act, 无权限时返回 auth.ErrPermissionDenied
+func (a *Authorizer) Authorize(ctx context.Context, obj, act string) error {
+	if a.adminBypass != nil && a.adminBypass(ctx) {
+		return nil
+	}
+
+	roleIds, err := rolectx.GetRoleIDFromCtx(ctx)
+	if err != nil {
+		return auth.ErrPermissionDenied
+	}
+
+	var domain string
+	if a.withDomain {
+		domain = strconv.FormatUint(tenantctx.GetTenantIDFromCtx(ctx), common.Ten)
+	}
+
+	for _, roleId := range roleIds {
+		if roleId == common.EmptyString {
+			continue
+		}
+
+		var ok bool
+		if a.withDomain {
+			ok, err = a.enforcer.Enforce(roleId, domain, obj, act)
+		} else {
+			ok, err = a.enforcer.Enforce(roleId, obj, act)
+		}
+		if err != nil {
+			logx.WithContext(ctx).Errorw("casbin enforce failed", logx.Field("detail", err.Error()))
+			return status.Error(codes.Internal, "common.internalError")
+		}
+		if ok {
+			return nil
+		}
+	}
+
+	logx.WithContext(ctx).Infow("permission denied", logx.Field("roleId", roleIds),
+		logx.Field("domain", domain), logx.Field("obj", obj), logx.Field("act", act))
+	return auth.ErrPermissionDenied
+}
+
+// UnaryServerInterceptor 返回 RPC 鉴权拦截器, 需注册在 auth.UnaryAuthInterceptor 之后
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !a.skip(info.FullMethod) {
+			if err := a.Authorize(ctx, info.FullMethod, rpcName(info.FullMethod)); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor 返回流式 RPC 鉴权拦截器, 需注册在 auth.StreamAuthInterceptor 之后
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !a.skip(info.FullMethod) {
+			if err := a.Authorize(ss.Context(), info.FullMethod, rpcName(info.FullMethod)); err != nil {
+				return err
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Handle HTTP 鉴权中间件, 需注册在 JWT 认证之后
+func (a *Authorizer) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := a.Authorize(r.Context(), r.URL.Path, r.Method)
+		if err != nil {
+			if status.Code(err) == codes.PermissionDenied {
+				httpx.ErrorCtx(r.Context(), w, errorx.NewApiForbiddenError("common.permissionDeny"))
+			} else {
+				httpx.ErrorCtx(r.Context(), w, errorx.NewApiInternalError("common.internalError"))
+			}
+			return
+		}
+		next(w, r)
+	}
+}
+
+// Middleware 返回可直接用于 rest.Server.Use 的中间件函数:
+//
+//	server.Use(casbin.NewAuthorizer(enforcer).Middleware())
+func (a *Authorizer) Middleware() rest.Middleware {
+	return a.Handle
+}
+
+func (a *Authorizer) skip(fullMethod string) bool {
+	for _, m := range a.skipMethods {
+		if auth.MatchMethod(m, fullMethod) {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcName 返回完整方法名 /pkg.Service/Method 中的 Method
+func rpcName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, common.Slash)+1:]
+}
@@ -0,0 +1,153 @@
+package casbin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/entctx/tenantctx"
+)
+
+const testModel = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub == p.sub && keyMatch2(r.obj,p.obj) && r.act == p.act
+`
+
+const testDomainModel = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = r.sub == p.sub && r.dom == p.dom && keyMatch2(r.obj,p.obj) && r.act == p.act
+`
+
+func newTestEnforcer(t *testing.T, text string, policies ...[]string) *casbin.Enforcer {
+	m, err := model.NewModelFromString(text)
+	assert.Nil(t, err)
+	e, err := casbin.NewEnforcer(m)
+	assert.Nil(t, err)
+	for _, p := range policies {
+		_, err = e.AddPolicy(p)
+		assert.Nil(t, err)
+	}
+	return e
+}
+
+func roleCtx(roles string) context.Context {
+	return context.WithValue(context.Background(), common.CtxKeyRoleID, roles)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	a := NewAuthorizer(newTestEnforcer(t, testModel, []string{"editor", "/api/users/:id", "GET"}))
+
+	assert.Nil(t, a.Authorize(roleCtx("guest,editor"), "/api/users/1", "GET"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.Authorize(roleCtx("guest"), "/api/users/1", "GET")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.Authorize(roleCtx("editor"), "/api/users/1", "DELETE")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(a.Authorize(context.Background(), "/api/users/1", "GET")))
+
+	// the tenant admin set by AdminCtx or propagated over gRPC bypasses the check by default
+	assert.Nil(t, a.Authorize(tenantctx.AdminCtx(roleCtx("guest")), "/api/users/1", "DELETE"))
+	admin := metadata.NewIncomingContext(roleCtx("guest"), metadata.Pairs(string(tenantctx.TenantAdmin), "allow"))
+	assert.Nil(t, a.Authorize(admin, "/api/users/1", "DELETE"))
+
+	a = NewAuthorizer(a.enforcer, WithAdminBypass(nil))
+	assert.NotNil(t, a.Authorize(tenantctx.AdminCtx(roleCtx("guest")), "/api/users/1", "DELETE"))
+}
+
+func TestAuthorizerTenantDomain(t *testing.T) {
+	a := NewAuthorizer(newTestEnforcer(t, testDomainModel, []string{"editor", "2", "/api/users", "GET"}),
+		WithTenantDomain())
+
+	assert.Nil(t, a.Authorize(tenantctx.WithTenantId(roleCtx("editor"), 2), "/api/users", "GET"))
+	assert.NotNil(t, a.Authorize(tenantctx.WithTenantId(roleCtx("editor"), 3), "/api/users", "GET"))
+}
+
+func TestAuthorizerUnaryServerInterceptor(t *testing.T) {
+	a := NewAuthorizer(newTestEnforcer(t, testModel, []string{"editor", "/core.Core/GetUser", "GetUser"}),
+		WithSkipMethods("/core.Public/*"))
+	interceptor := a.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	call := func(ctx context.Context, method string) error {
+		resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		if err == nil {
+			assert.Equal(t, "ok", resp)
+		}
+		return err
+	}
+
+	assert.Nil(t, call(roleCtx("editor"), "/core.Core/GetUser"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(roleCtx("editor"), "/core.Core/DeleteUser")))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(roleCtx("guest"), "/core.Core/GetUser")))
+	assert.Nil(t, call(context.Background(), "/core.Public/Ping"))
+}
+
+func TestAuthorizerStreamServerInterceptor(t *testing.T) {
+	a := NewAuthorizer(newTestEnforcer(t, testModel, []string{"editor", "/core.Core/Watch", "Watch"}))
+	interceptor := a.StreamServerInterceptor()
+	var called int
+	handler := func(srv any, ss grpc.ServerStream) error {
+		called++
+		return nil
+	}
+	call := func(ctx context.Context, method string) error {
+		return interceptor(nil, testServerStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: method}, handler)
+	}
+
+	assert.Nil(t, call(roleCtx("editor"), "/core.Core/Watch"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(call(roleCtx("guest"), "/core.Core/Watch")))
+	assert.Equal(t, 1, called)
+}
+
+func TestAuthorizerMiddleware(t *testing.T) {
+	a := NewAuthorizer(newTestEnforcer(t, testModel, []string{"editor", "/api/users/:id", "GET"}))
+	handler := a.Middleware()(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	serve := func(ctx context.Context, method, path string) int {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(method, path, nil).WithContext(ctx))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(roleCtx("editor"), http.MethodGet, "/api/users/1"))
+	assert.Equal(t, http.StatusForbidden, serve(roleCtx("editor"), http.MethodDelete, "/api/users/1"))
+	assert.Equal(t, http.StatusForbidden, serve(context.Background(), http.MethodGet, "/api/users/1"))
+}
@@ -18,8 +18,10 @@ import (
 	"crypto/tls"
 	"fmt"
 	"log"
+	"time"
 
 	"mingyang.com/admin-common/config"
+	"mingyang.com/admin-common/pkg/bootstrap"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
@@ -34,6 +36,9 @@ import (
 // CasbinConf is the configuration structure for Casbin
 type CasbinConf struct {
 	ModelText string `json:"ModelText,optional,env=CASBIN_MODEL_TEXT"`
+	// ReconcileInterval is the interval of comparing the policies with the database when using the redis watcher,
+	// such as 5m. It is disabled by default.
+	ReconcileInterval time.Duration `json:"ReconcileInterval,optional,env=CASBIN_RECONCILE_INTERVAL"`
 }
 
 // NewCasbin returns Casbin enforcer.
@@ -90,21 +95,7 @@ func (l CasbinConf) MustNewCasbin(dbType, dsn string) *casbin.Enforcer {
 // MustNewRedisWatcher returns redis watcher. If there are errors, it will exist.
 // f function will be called if the policies are updated.
 func (l CasbinConf) MustNewRedisWatcher(c redis.RedisConf, f func(string2 string)) persist.Watcher {
-	opt := redis2.Options{
-		Network:  "tcp",
-		Username: c.User,
-		Password: c.Pass,
-	}
-
-	if c.Tls {
-		opt.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
-	}
-
-	w, err := rediswatcher.NewWatcher(c.Host, rediswatcher.WatcherOptions{
-		Options:    opt,
-		Channel:    config.RedisCasbinChannel,
-		IgnoreSelf: false,
-	})
+	w, err := rediswatcher.NewWatcher(c.Host, redisWatcherOptions(c))
 	logx.Must(err)
 
 	err = w.SetUpdateCallback(f)
@@ -117,22 +108,70 @@ func (l CasbinConf) MustNewRedisWatcher(c redis.RedisConf, f func(string2 string
 // MustNewCasbin 内部已 LoadPolicy 把策略加载到内存。不要在启动时 SavePolicy：
 // ent-adapter 的 SavePolicy 实现是 DELETE FROM casbin_rule + 批量 INSERT，
 // 多实例并发启动或与其他进程写策略并发时，会把别处刚加的策略一并擦掉。
+// 策略变更以增量消息同步，设置 ReconcileInterval 后定时与数据库校验。
 func (l CasbinConf) MustNewCasbinWithRedisWatcher(dbType, dsn string, c redis.RedisConf) *casbin.Enforcer {
 	cbn := l.MustNewCasbin(dbType, dsn)
-	w := l.MustNewRedisWatcher(c, func(data string) {
-		rediswatcher.DefaultUpdateCallback(cbn)(data)
-	})
-	err := cbn.SetWatcher(w)
-	logx.Must(err)
+	l.mustSetIncrementalWatcher(cbn, c.Host, redisWatcherOptions(c))
 	return cbn
 }
 
 // MustNewOriginalRedisWatcher returns redis watcher which uses original go redis. If there are errors, it will exist.
 // f function will be called if the policies are updated.
 func (l CasbinConf) MustNewOriginalRedisWatcher(c config.RedisConf, f func(string2 string)) persist.Watcher {
+	w, err := rediswatcher.NewWatcher(c.Host, originalWatcherOptions(c))
+	logx.Must(err)
+
+	err = w.SetUpdateCallback(f)
+	logx.Must(err)
+
+	return w
+}
+
+// MustNewCasbinWithOriginalRedisWatcher returns Casbin Enforcer with original Redis watcher.
+// 启动只 LoadPolicy（在 MustNewCasbin 内已完成），不再 SavePolicy，避免清表后用本进程内存
+// 快照覆盖其他实例并发写入的策略。
+func (l CasbinConf) MustNewCasbinWithOriginalRedisWatcher(dbType, dsn string, c config.RedisConf) *casbin.Enforcer {
+	cbn := l.MustNewCasbin(dbType, dsn)
+	l.mustSetIncrementalWatcher(cbn, c.Host, originalWatcherOptions(c))
+	return cbn
+}
+
+// mustSetIncrementalWatcher sets the watcher which publishes and applies the incremental policy messages,
+// and starts the policy reconciler if ReconcileInterval is set. Both are stopped on shutdown.
+// 本实例的变更已写入内存, 忽略自己发布的消息, 避免重复应用与乱序。
+func (l CasbinConf) mustSetIncrementalWatcher(cbn *casbin.Enforcer, addr string, opt rediswatcher.WatcherOptions) {
+	opt.IgnoreSelf = true
+	opt.OptionalUpdateCallback = IncrementalUpdateCallback(cbn)
+	w, err := rediswatcher.NewWatcher(addr, opt)
+	logx.Must(err)
+
+	err = cbn.SetWatcher(w)
+	logx.Must(err)
+	bootstrap.AddCloser("casbin-watcher", closerFunc(func() error {
+		w.Close()
+		return nil
+	}))
+
+	if l.ReconcileInterval > 0 {
+		stop := StartPolicyReconciler(cbn, l.ReconcileInterval)
+		bootstrap.AddCloser("casbin-reconciler", closerFunc(func() error {
+			stop()
+			return nil
+		}))
+	}
+}
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// redisWatcherOptions returns the watcher options of the go zero redis config.
+func redisWatcherOptions(c redis.RedisConf) rediswatcher.WatcherOptions {
 	opt := redis2.Options{
 		Network:  "tcp",
-		Username: c.Username,
+		Username: c.User,
 		Password: c.Pass,
 	}
 
@@ -140,28 +179,33 @@ func (l CasbinConf) MustNewOriginalRedisWatcher(c config.RedisConf, f func(strin
 		opt.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
 
-	w, err := rediswatcher.NewWatcher(c.Host, rediswatcher.WatcherOptions{
+	return rediswatcher.WatcherOptions{
 		Options:    opt,
-		Channel:    fmt.Sprintf("%s-%d", config.RedisCasbinChannel, c.Db),
+		Channel:    config.RedisCasbinChannel,
 		IgnoreSelf: false,
-	})
-	logx.Must(err)
+	}
+}
 
-	err = w.SetUpdateCallback(f)
-	logx.Must(err)
+// originalWatcherOptions returns the watcher options of the original redis config.
+func originalWatcherOptions(c config.RedisConf) rediswatcher.WatcherOptions {
+	opt := redis2.Options{
+		Network:  "tcp",
+		Username: c.Username,
+		Password: c.Pass,
+	}
 
-	return w
+	if c.Tls {
+		opt.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return rediswatcher.WatcherOptions{
+		Options:    opt,
+		Channel:    originalWatcherChannel(c),
+		IgnoreSelf: false,
+	}
 }
 
-// MustNewCasbinWithOriginalRedisWatcher returns Casbin Enforcer with original Redis watcher.
-// 启动只 LoadPolicy（在 MustNewCasbin 内已完成），不再 SavePolicy，避免清表后用本进程内存
-// 快照覆盖其他实例并发写入的策略。
-func (l CasbinConf) MustNewCasbinWithOriginalRedisWatcher(dbType, dsn string, c config.RedisConf) *casbin.Enforcer {
-	cbn := l.MustNewCasbin(dbType, dsn)
-	w := l.MustNewOriginalRedisWatcher(c, func(data string) {
-		rediswatcher.DefaultUpdateCallback(cbn)(data)
-	})
-	err := cbn.SetWatcher(w)
-	logx.Must(err)
-	return cbn
+// originalWatcherChannel returns the casbin channel of the redis db.
+func originalWatcherChannel(c config.RedisConf) string {
+	return fmt.Sprintf("%s-%d", config.RedisCasbinChannel, c.Db)
 }
@@ -0,0 +1,291 @@
+package casbin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	entadapter "github.com/casbin/ent-adapter"
+	rediswatcher "github.com/casbin/redis-watcher/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	"mingyang.com/admin-common/config"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/pkg/bootstrap"
+	"mingyang.com/admin-common/utils/uuidx"
+)
+
+// TenantModelText RBAC with domains 模型, dom 为租户 ID:
+//
+//	p = 角色, 租户, 路径, 方法
+//	g = 用户, 角色, 租户
+//
+// 自定义 ModelText 时需保持相同的字段顺序, 租户相关方法按该顺序过滤策略。
+const TenantModelText = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && keyMatch2(r.obj, p.obj) && r.act == p.act
+`
+
+// UpdateForTenant 租户策略变更消息, FieldValues[0] 为租户 ID, 收到后只重新加载该租户的策略
+const UpdateForTenant rediswatcher.UpdateType = "UpdateForTenant"
+
+// TenantCasbin 按租户隔离策略的 Casbin 执行器, 可直接用于 NewAuthorizer(tc, WithTenantDomain())。
+//
+// 通过租户方法修改策略时会在 watcher channel 上广播 UpdateForTenant 消息,
+// 其他实例只重新加载该租户的策略; 直接调用内嵌 SyncedEnforcer 的方法修改策略后需调用 NotifyTenant。
+// 同一 channel 上使用 MustNewCasbinWithOriginalRedisWatcher 的实例无法识别该消息, 不应混用。
+type TenantCasbin struct {
+	*casbin.SyncedEnforcer
+	modelText string
+	// loader 只用于按租户加载策略, ent-adapter 加载过滤策略后会被标记为 filtered, 不能与执行器共用
+	loader  *entadapter.Adapter
+	rds     redis.UniversalClient
+	watcher persist.Watcher
+	channel string
+	localID string
+}
+
+// NewTenantCasbin returns the Casbin enforcer with tenant domains.
+func (l CasbinConf) NewTenantCasbin(dbType, dsn string) (*TenantCasbin, error) {
+	text := l.ModelText
+	if text == common.EmptyString {
+		text = TenantModelText
+	}
+
+	m, err := model.NewModelFromString(text)
+	if err != nil {
+		return nil, err
+	}
+
+	adapter, err := entadapter.NewAdapter(dbType, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	loader, err := entadapter.NewAdapter(dbType, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	enforcer, err := casbin.NewSyncedEnforcer(m, adapter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = enforcer.LoadPolicy(); err != nil {
+		return nil, err
+	}
+
+	return &TenantCasbin{SyncedEnforcer: enforcer, modelText: text, loader: loader}, nil
+}
+
+// MustNewTenantCasbin returns the Casbin enforcer with tenant domains. If there are errors, it will exit.
+func (l CasbinConf) MustNewTenantCasbin(dbType, dsn string) *TenantCasbin {
+	tc, err := l.NewTenantCasbin(dbType, dsn)
+	logx.Must(err)
+	return tc
+}
+
+// MustNewTenantCasbinWithRedisWatcher returns the Casbin enforcer with tenant domains, the policy changes
+// are broadcast through the original redis watcher channel. The watcher and redis client are closed
+// by Close, which is registered to be called on shutdown. If there are errors, it will exit.
+func (l CasbinConf) MustNewTenantCasbinWithRedisWatcher(dbType, dsn string, c config.RedisConf) *TenantCasbin {
+	tc := l.MustNewTenantCasbin(dbType, dsn)
+	tc.rds = c.MustNewUniversalRedis()
+	tc.channel = originalWatcherChannel(c)
+	tc.localID = uuidx.NewUUID().String()
+
+	opt := originalWatcherOptions(c)
+	opt.LocalID = tc.localID
+	opt.OptionalUpdateCallback = tc.updateCallback
+	w, err := rediswatcher.NewWatcher(c.Host, opt)
+	logx.Must(err)
+	tc.watcher = w
+	bootstrap.AddCloser("casbin-watcher", tc)
+
+	return tc
+}
+
+// Close 关闭 redis watcher 与发布消息的 redis 客户端, 未配置 redis watcher 时不做任何操作
+func (t *TenantCasbin) Close() error {
+	if t.watcher != nil {
+		t.watcher.Close()
+		t.watcher = nil
+	}
+	if t.rds == nil {
+		return nil
+	}
+	return t.rds.Close()
+}
+
+// GrantPermission 允许租户内的角色访问 obj 的 act 方法
+func (t *TenantCasbin) GrantPermission(ctx context.Context, tenantId uint64, role, obj, act string) error {
+	if _, err := t.AddPolicy(role, tenantDomain(tenantId), obj, act); err != nil {
+		return err
+	}
+	return t.NotifyTenant(ctx, tenantId)
+}
+
+// RevokePermission 取消租户内角色对 obj 的 act 方法的访问权限
+func (t *TenantCasbin) RevokePermission(ctx context.Context, tenantId uint64, role, obj, act string) error {
+	if _, err := t.RemovePolicy(role, tenantDomain(tenantId), obj, act); err != nil {
+		return err
+	}
+	return t.NotifyTenant(ctx, tenantId)
+}
+
+// SetRolePermissions 在事务中替换租户内角色的全部权限, permissions 每项为 {obj, act}
+func (t *TenantCasbin) SetRolePermissions(ctx context.Context, tenantId uint64, role string, permissions [][]string) error {
+	dom := tenantDomain(tenantId)
+	rules := make([][]string, 0, len(permissions))
+	for _, v := range permissions {
+		rules = append(rules, append([]string{role, dom}, v...))
+	}
+
+	if _, err := t.UpdateFilteredPolicies(rules, 0, role, dom); err != nil {
+		return err
+	}
+	return t.NotifyTenant(ctx, tenantId)
+}
+
+// AssignRole 在租户内为用户分配角色
+func (t *TenantCasbin) AssignRole(ctx context.Context, tenantId uint64, user, role string) error {
+	if _, err := t.AddGroupingPolicy(user, role, tenantDomain(tenantId)); err != nil {
+		return err
+	}
+	return t.NotifyTenant(ctx, tenantId)
+}
+
+// UnassignRole 取消用户在租户内的角色
+func (t *TenantCasbin) UnassignRole(ctx context.Context, tenantId uint64, user, role string) error {
+	if _, err := t.RemoveGroupingPolicy(user, role, tenantDomain(tenantId)); err != nil {
+		return err
+	}
+	return t.NotifyTenant(ctx, tenantId)
+}
+
+// RemoveTenant 删除租户的全部权限与角色分配
+func (t *TenantCasbin) RemoveTenant(ctx context.Context, tenantId uint64) error {
+	dom := tenantDomain(tenantId)
+	if _, err := t.RemoveFilteredPolicy(1, dom); err != nil {
+		return err
+	}
+	if _, err := t.RemoveFilteredGroupingPolicy(2, dom); err != nil {
+		return err
+	}
+	return t.NotifyTenant(ctx, tenantId)
+}
+
+// ListPermissions 返回租户内的全部权限, 每项为 {role, tenantId, obj, act}
+func (t *TenantCasbin) ListPermissions(tenantId uint64) ([][]string, error) {
+	return t.GetFilteredPolicy(1, tenantDomain(tenantId))
+}
+
+// ListRolePermissions 返回租户内角色的权限, 每项为 {role, tenantId, obj, act}
+func (t *TenantCasbin) ListRolePermissions(tenantId uint64, role string) ([][]string, error) {
+	return t.GetFilteredPolicy(0, role, tenantDomain(tenantId))
+}
+
+// ListRoleAssignments 返回租户内的角色分配, 每项为 {user, role, tenantId}
+func (t *TenantCasbin) ListRoleAssignments(tenantId uint64) ([][]string, error) {
+	return t.GetFilteredGroupingPolicy(2, tenantDomain(tenantId))
+}
+
+// NotifyTenant 通知其他实例重新加载租户策略, 未配置 redis watcher 时不做任何操作
+func (t *TenantCasbin) NotifyTenant(ctx context.Context, tenantId uint64) error {
+	if t.rds == nil {
+		return nil
+	}
+
+	return t.rds.Publish(ctx, t.channel, &rediswatcher.MSG{
+		Method:      UpdateForTenant,
+		ID:          t.localID,
+		FieldValues: []string{tenantDomain(tenantId)},
+	}).Err()
+}
+
+// ReloadTenant 从数据库重新加载租户策略, 只增删有变化的策略, 加载期间不影响其他租户的鉴权
+func (t *TenantCasbin) ReloadTenant(tenantId uint64) error {
+	dom := tenantDomain(tenantId)
+
+	m, err := model.NewModelFromString(t.modelText)
+	if err != nil {
+		return err
+	}
+	if err = t.loader.LoadFilteredPolicy(m, entadapter.Filter{Ptype: []string{"p"}, V1: []string{dom}}); err != nil {
+		return err
+	}
+	if err = t.loader.LoadFilteredPolicy(m, entadapter.Filter{Ptype: []string{"g"}, V2: []string{dom}}); err != nil {
+		return err
+	}
+
+	policies, err := m.GetPolicy("p", "p")
+	if err != nil {
+		return err
+	}
+	current, err := t.GetFilteredPolicy(1, dom)
+	if err != nil {
+		return err
+	}
+	if err = syncPolicies(t.SyncedEnforcer, "p", "p", current, policies); err != nil {
+		return err
+	}
+
+	groups, err := m.GetPolicy("g", "g")
+	if err != nil {
+		return err
+	}
+	current, err = t.GetFilteredGroupingPolicy(2, dom)
+	if err != nil {
+		return err
+	}
+	return syncPolicies(t.SyncedEnforcer, "g", "g", current, groups)
+}
+
+// updateCallback 处理租户消息, 其他消息按增量方式处理
+func (t *TenantCasbin) updateCallback(data string) {
+	msg := &rediswatcher.MSG{}
+	if err := msg.UnmarshalBinary([]byte(data)); err != nil {
+		logx.Errorw("failed to parse casbin watcher message", logx.Field("detail", err.Error()))
+		return
+	}
+
+	if msg.Method != UpdateForTenant {
+		IncrementalUpdateCallback(t.SyncedEnforcer)(data)
+		return
+	}
+
+	// 本实例的修改已经写入内存
+	if msg.ID == t.localID || len(msg.FieldValues) == common.Zero {
+		return
+	}
+
+	tenantId, err := strconv.ParseUint(msg.FieldValues[0], common.Ten, 64)
+	if err != nil {
+		logx.Errorw("invalid tenant id in casbin watcher message", logx.Field("detail", msg.FieldValues[0]))
+		return
+	}
+
+	if err = t.ReloadTenant(tenantId); err != nil {
+		logx.Errorw("failed to reload tenant casbin policies", logx.Field("tenantId", tenantId),
+			logx.Field("detail", err.Error()))
+	}
+}
+
+func tenantDomain(tenantId uint64) string {
+	return strconv.FormatUint(tenantId, common.Ten)
+}
@@ -0,0 +1,116 @@
+package casbin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	rediswatcher "github.com/casbin/redis-watcher/v2"
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/config"
+	"mingyang.com/admin-common/pkg/bootstrap"
+)
+
+func newTestTenantCasbins(t *testing.T) (*TenantCasbin, *TenantCasbin, string) {
+	dsn := fmt.Sprintf("file:%s?_fk=1", filepath.Join(t.TempDir(), "casbin.db"))
+	t1, err := CasbinConf{}.NewTenantCasbin("sqlite3", dsn)
+	assert.Nil(t, err)
+	t2, err := CasbinConf{}.NewTenantCasbin("sqlite3", dsn)
+	assert.Nil(t, err)
+	return t1, t2, dsn
+}
+
+func TestTenantCasbinReloadTenant(t *testing.T) {
+	ctx := context.Background()
+	t1, t2, dsn := newTestTenantCasbins(t)
+	db := openRuleDB(t, dsn)
+
+	assert.Nil(t, t1.GrantPermission(ctx, 2, "editor", "/api/users", "GET"))
+	assert.Nil(t, t1.AssignRole(ctx, 2, "alice", "editor"))
+	assert.Nil(t, t1.GrantPermission(ctx, 3, "editor", "/api/users", "GET"))
+
+	// only the policies of the tenant are loaded, and they are not written to the database again
+	assert.Nil(t, t2.ReloadTenant(2))
+	ok, err := t2.Enforce("alice", "2", "/api/users", "GET")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	permissions, _ := t2.ListPermissions(3)
+	assert.Empty(t, permissions)
+	assert.Equal(t, 3, countRules(t, db))
+
+	assert.Nil(t, t1.SetRolePermissions(ctx, 2, "editor", [][]string{{"/api/users", "POST"}}))
+	assert.Nil(t, t1.UnassignRole(ctx, 2, "alice", "editor"))
+	assert.Nil(t, t2.ReloadTenant(2))
+	permissions, _ = t2.ListRolePermissions(2, "editor")
+	assert.Equal(t, [][]string{{"editor", "2", "/api/users", "POST"}}, permissions)
+	assignments, _ := t2.ListRoleAssignments(2)
+	assert.Empty(t, assignments)
+	assert.Equal(t, 2, countRules(t, db))
+}
+
+func TestTenantCasbinUpdateCallback(t *testing.T) {
+	ctx := context.Background()
+	t1, t2, _ := newTestTenantCasbins(t)
+	t2.localID = "t2"
+
+	assert.Nil(t, t1.GrantPermission(ctx, 2, "editor", "/api/users", "GET"))
+	// the message of itself is ignored
+	t2.updateCallback(watcherMessage(t, rediswatcher.MSG{Method: UpdateForTenant, ID: "t2", FieldValues: []string{"2"}}))
+	ok, _ := t2.Enforce("editor", "2", "/api/users", "GET")
+	assert.False(t, ok)
+
+	t2.updateCallback(watcherMessage(t, rediswatcher.MSG{Method: UpdateForTenant, ID: "t1", FieldValues: []string{"2"}}))
+	ok, _ = t2.Enforce("editor", "2", "/api/users", "GET")
+	assert.True(t, ok)
+
+	assert.Nil(t, t1.RemoveTenant(ctx, 2))
+	t2.updateCallback(watcherMessage(t, rediswatcher.MSG{Method: UpdateForTenant, ID: "t1", FieldValues: []string{"2"}}))
+	ok, _ = t2.Enforce("editor", "2", "/api/users", "GET")
+	assert.False(t, ok)
+}
+
+func TestMustNewTenantCasbinWithRedisWatcher(t *testing.T) {
+	ctx := context.Background()
+	rds := miniredis.RunT(t)
+	dsn := fmt.Sprintf("file:%s?_fk=1", filepath.Join(t.TempDir(), "casbin.db"))
+	c := config.RedisConf{Host: rds.Addr()}
+
+	t1 := CasbinConf{}.MustNewTenantCasbinWithRedisWatcher("sqlite3", dsn, c)
+	t2 := CasbinConf{}.MustNewTenantCasbinWithRedisWatcher("sqlite3", dsn, c)
+	defer t2.Close()
+	// the watchers subscribe in background
+	assert.Eventually(t, func() bool {
+		return rds.PubSubNumSub(t1.channel)[t1.channel] == 2
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Nil(t, t1.GrantPermission(ctx, 2, "editor", "/api/users", "GET"))
+	assert.Eventually(t, func() bool {
+		ok, _ := t2.Enforce("editor", "2", "/api/users", "GET")
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	// the watcher and the redis client are closed
+	assert.Nil(t, t1.Close())
+	assert.NotNil(t, t1.NotifyTenant(ctx, 2))
+}
+
+func TestMustNewCasbinWithOriginalRedisWatcher(t *testing.T) {
+	rds := miniredis.RunT(t)
+	dsn := fmt.Sprintf("file:%s?_fk=1", filepath.Join(t.TempDir(), "casbin.db"))
+	c := config.RedisConf{Host: rds.Addr()}
+
+	CasbinConf{ReconcileInterval: time.Minute}.MustNewCasbinWithOriginalRedisWatcher("sqlite3", dsn, c)
+	channel := originalWatcherChannel(c)
+	assert.Eventually(t, func() bool {
+		return rds.PubSubNumSub(channel)[channel] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the watcher is closed on shutdown, the errors of the resources closed by other tests are ignored
+	_ = bootstrap.Close()
+	assert.Eventually(t, func() bool {
+		return rds.PubSubNumSub(channel)[channel] == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,245 @@
+package casbin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	rediswatcher "github.com/casbin/redis-watcher/v2"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+)
+
+// IncrementalUpdateCallback returns the watcher callback which applies the incremental policy messages.
+// The Update and UpdateForSavePolicy messages are applied by ReconcilePolicy, so only the changed
+// policies are written to the enforcer instead of a full LoadPolicy.
+//
+// The messages are applied to the model of the enforcer only, the policies have been saved by the sender.
+func IncrementalUpdateCallback(e casbin.IEnforcer) func(string) {
+	return func(data string) {
+		msg := &rediswatcher.MSG{}
+		if err := msg.UnmarshalBinary([]byte(data)); err != nil {
+			logx.Errorw("failed to parse casbin watcher message", logx.Field("detail", err.Error()))
+			return
+		}
+
+		var err error
+		switch msg.Method {
+		case rediswatcher.Update, rediswatcher.UpdateForSavePolicy:
+			_, err = ReconcilePolicy(e)
+		case rediswatcher.UpdateForAddPolicy:
+			err = applyPolicies(e, msg.Sec, msg.Ptype, nil, [][]string{msg.NewRule})
+		case rediswatcher.UpdateForAddPolicies:
+			err = applyPolicies(e, msg.Sec, msg.Ptype, nil, msg.NewRules)
+		case rediswatcher.UpdateForRemovePolicy:
+			err = applyPolicies(e, msg.Sec, msg.Ptype, [][]string{msg.NewRule}, nil)
+		case rediswatcher.UpdateForRemoveFilteredPolicy:
+			err = applyFilteredRemoval(e, msg.Sec, msg.Ptype, msg.FieldIndex, msg.FieldValues...)
+		case rediswatcher.UpdateForRemovePolicies:
+			err = applyPolicies(e, msg.Sec, msg.Ptype, msg.NewRules, nil)
+		case rediswatcher.UpdateForUpdatePolicy:
+			err = applyPolicies(e, msg.Sec, msg.Ptype, [][]string{msg.OldRule}, [][]string{msg.NewRule})
+		case rediswatcher.UpdateForUpdatePolicies:
+			err = applyPolicies(e, msg.Sec, msg.Ptype, msg.OldRules, msg.NewRules)
+		default:
+			logx.Errorw("unknown casbin watcher message", logx.Field("method", msg.Method))
+			return
+		}
+
+		// 已存在或已删除的策略会被跳过, 不视为错误, 遗漏的变更由定时校验修复
+		if err != nil {
+			logx.Errorw("failed to apply casbin watcher message", logx.Field("method", msg.Method),
+				logx.Field("detail", err.Error()))
+		}
+	}
+}
+
+// ReconcilePolicy loads all policies from the adapter into a new model and compares the checksum with
+// the policies of the enforcer. The different policies are applied to the model of the enforcer if the
+// checksum changed, nothing is written to the adapter.
+func ReconcilePolicy(e casbin.IEnforcer) (bool, error) {
+	m, err := model.NewModelFromString(e.GetModel().ToText())
+	if err != nil {
+		return false, err
+	}
+	if err = e.GetAdapter().LoadPolicy(m); err != nil {
+		return false, err
+	}
+
+	var changed bool
+	for _, sec := range []string{"p", "g"} {
+		for ptype, ast := range m[sec] {
+			var current [][]string
+			if sec == "p" {
+				current, err = e.GetNamedPolicy(ptype)
+			} else {
+				current, err = e.GetNamedGroupingPolicy(ptype)
+			}
+			if err != nil {
+				return changed, err
+			}
+
+			if PolicyChecksum(current) == PolicyChecksum(ast.Policy) {
+				continue
+			}
+
+			changed = true
+			if err = syncPolicies(e, sec, ptype, current, ast.Policy); err != nil {
+				return changed, err
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// PolicyChecksum returns the checksum of the policies regardless of the order.
+func PolicyChecksum(policies [][]string) string {
+	lines := make([]string, 0, len(policies))
+	for _, v := range policies {
+		lines = append(lines, policyKey(v))
+	}
+	slices.Sort(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// StartPolicyReconciler calls ReconcilePolicy every interval to repair the missed watcher messages.
+// Call the returned function to stop it.
+func StartPolicyReconciler(e casbin.IEnforcer, interval time.Duration) func() {
+	done := make(chan struct{})
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				changed, err := ReconcilePolicy(e)
+				if err != nil {
+					logx.Errorw("failed to reconcile casbin policies", logx.Field("detail", err.Error()))
+				} else if changed {
+					logx.Infow("casbin policies are reconciled with the database")
+				}
+			}
+		}
+	})
+
+	return func() {
+		close(done)
+	}
+}
+
+// syncPolicies 在内存中增删 current 与 desired 的差异
+func syncPolicies(e casbin.IEnforcer, sec, ptype string, current, desired [][]string) error {
+	return applyPolicies(e, sec, ptype, subtractPolicies(current, desired), subtractPolicies(desired, current))
+}
+
+// applyPolicies 在 model 中删除 remove 并添加 add, 同时更新角色关系与决策缓存。
+//
+// 不能使用 enforcer 的 Self* 方法: 开启 autoSave 时它们同样会写入 adapter,
+// 策略已由发送方写入数据库, 再次插入会违反 casbin_rule 的唯一索引, 导致内存中的 model 没有更新。
+func applyPolicies(e casbin.IEnforcer, sec, ptype string, remove, add [][]string) error {
+	if len(remove) == 0 && len(add) == 0 {
+		return nil
+	}
+
+	unlock := lockEnforcer(e)
+	defer unlock()
+
+	m := e.GetModel()
+	removed, err := m.RemovePoliciesWithAffected(sec, ptype, remove)
+	if err != nil {
+		return err
+	}
+	if err = policiesChanged(e, model.PolicyRemove, sec, ptype, removed); err != nil {
+		return err
+	}
+
+	added, err := m.AddPoliciesWithAffected(sec, ptype, add)
+	if err != nil {
+		return err
+	}
+	return policiesChanged(e, model.PolicyAdd, sec, ptype, added)
+}
+
+// applyFilteredRemoval 在 model 中删除匹配字段的策略, 同时更新角色关系与决策缓存
+func applyFilteredRemoval(e casbin.IEnforcer, sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	unlock := lockEnforcer(e)
+	defer unlock()
+
+	_, removed, err := e.GetModel().RemoveFilteredPolicy(sec, ptype, fieldIndex, fieldValues...)
+	if err != nil {
+		return err
+	}
+	return policiesChanged(e, model.PolicyRemove, sec, ptype, removed)
+}
+
+// lockEnforcer 对 SyncedEnforcer 加写锁, 与其读写策略和鉴权互斥, 其他 enforcer 不加锁
+func lockEnforcer(e casbin.IEnforcer) func() {
+	l, ok := e.(interface{ GetLock() *sync.RWMutex })
+	if !ok {
+		return func() {}
+	}
+
+	l.GetLock().Lock()
+	return l.GetLock().Unlock
+}
+
+// policiesChanged 在 model 变更后更新 g 策略的角色关系, 并清空 CachedEnforcer 的决策缓存
+func policiesChanged(e casbin.IEnforcer, op model.PolicyOp, sec, ptype string, rules [][]string) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	if sec == "g" {
+		if b, ok := e.(roleLinksBuilder); ok {
+			if err := b.BuildIncrementalRoleLinks(op, ptype, rules); err != nil {
+				return err
+			}
+			if err := b.BuildIncrementalConditionalRoleLinks(op, ptype, rules); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c, ok := e.(interface{ InvalidateCache() error }); ok {
+		return c.InvalidateCache()
+	}
+	return nil
+}
+
+// roleLinksBuilder 是 casbin.Enforcer 增量构建角色关系的方法
+type roleLinksBuilder interface {
+	BuildIncrementalRoleLinks(op model.PolicyOp, ptype string, rules [][]string) error
+	BuildIncrementalConditionalRoleLinks(op model.PolicyOp, ptype string, rules [][]string) error
+}
+
+// subtractPolicies 返回在 a 中但不在 b 中的策略
+func subtractPolicies(a, b [][]string) [][]string {
+	exists := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		exists[policyKey(v)] = struct{}{}
+	}
+
+	var result [][]string
+	for _, v := range a {
+		if _, ok := exists[policyKey(v)]; !ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// policyKey 以不可见字符连接策略字段, 避免字段中的逗号造成歧义
+func policyKey(rule []string) string {
+	return strings.Join(rule, "\x1f")
+}
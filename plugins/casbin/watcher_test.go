@@ -0,0 +1,138 @@
+package casbin
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	rediswatcher "github.com/casbin/redis-watcher/v2"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestEnforcers returns two enforcers on the same database like two instances of a service.
+func newTestEnforcers(t *testing.T) (*casbin.Enforcer, *casbin.Enforcer, *sql.DB) {
+	dsn := fmt.Sprintf("file:%s?_fk=1", filepath.Join(t.TempDir(), "casbin.db"))
+	e1, err := CasbinConf{}.NewCasbin("sqlite3", dsn)
+	assert.Nil(t, err)
+	e2, err := CasbinConf{}.NewCasbin("sqlite3", dsn)
+	assert.Nil(t, err)
+	return e1, e2, openRuleDB(t, dsn)
+}
+
+// openRuleDB opens the database of the adapters after the table is created. ent-adapter declares the
+// unique index of the rules but does not create it, so it is created like the migrated databases.
+func openRuleDB(t *testing.T, dsn string) *sql.DB {
+	db, err := sql.Open("sqlite3", dsn)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	_, err = db.Exec("CREATE UNIQUE INDEX casbin_rule_unique ON casbin_rules (Ptype, V0, V1, V2, V3, V4, V5)")
+	assert.Nil(t, err)
+	return db
+}
+
+func countRules(t *testing.T, db *sql.DB) int {
+	var n int
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM casbin_rules").Scan(&n))
+	return n
+}
+
+func watcherMessage(t *testing.T, msg rediswatcher.MSG) string {
+	data, err := msg.MarshalBinary()
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestIncrementalUpdateCallback(t *testing.T) {
+	e1, e2, db := newTestEnforcers(t)
+	callback := IncrementalUpdateCallback(e2)
+	rule := []string{"editor", "/api/users", "GET"}
+
+	_, err := e1.AddPolicy(rule)
+	assert.Nil(t, err)
+	callback(watcherMessage(t, rediswatcher.MSG{Method: rediswatcher.UpdateForAddPolicy, Sec: "p", Ptype: "p",
+		NewRule: rule}))
+	ok, err := e2.Enforce("editor", "/api/users", "GET")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	// the message is applied to the model only, the rule is saved by the sender
+	assert.Equal(t, 1, countRules(t, db))
+
+	// the roles are linked
+	_, err = e1.AddGroupingPolicy("alice", "editor")
+	assert.Nil(t, err)
+	callback(watcherMessage(t, rediswatcher.MSG{Method: rediswatcher.UpdateForAddPolicies, Sec: "g", Ptype: "g",
+		NewRules: [][]string{{"alice", "editor"}}}))
+	roles, _ := e2.GetRolesForUser("alice")
+	assert.Equal(t, []string{"editor"}, roles)
+
+	newRule := []string{"editor", "/api/users", "POST"}
+	_, err = e1.UpdatePolicy(rule, newRule)
+	assert.Nil(t, err)
+	callback(watcherMessage(t, rediswatcher.MSG{Method: rediswatcher.UpdateForUpdatePolicy, Sec: "p", Ptype: "p",
+		OldRule: rule, NewRule: newRule}))
+	ok, _ = e2.Enforce("editor", "/api/users", "GET")
+	assert.False(t, ok)
+	ok, _ = e2.Enforce("editor", "/api/users", "POST")
+	assert.True(t, ok)
+
+	_, err = e1.RemoveFilteredGroupingPolicy(0, "alice")
+	assert.Nil(t, err)
+	callback(watcherMessage(t, rediswatcher.MSG{Method: rediswatcher.UpdateForRemoveFilteredPolicy, Sec: "g",
+		Ptype: "g", FieldIndex: 0, FieldValues: []string{"alice"}}))
+	roles, _ = e2.GetRolesForUser("alice")
+	assert.Empty(t, roles)
+
+	_, err = e1.RemovePolicy(newRule)
+	assert.Nil(t, err)
+	callback(watcherMessage(t, rediswatcher.MSG{Method: rediswatcher.UpdateForRemovePolicy, Sec: "p", Ptype: "p",
+		NewRule: newRule}))
+	ok, _ = e2.Enforce("editor", "/api/users", "POST")
+	assert.False(t, ok)
+
+	assert.Equal(t, 0, countRules(t, db))
+}
+
+func TestReconcilePolicy(t *testing.T) {
+	e1, e2, db := newTestEnforcers(t)
+
+	_, err := e1.AddPolicies([][]string{{"editor", "/api/users", "GET"}, {"admin", "/api/users", "DELETE"}})
+	assert.Nil(t, err)
+	_, err = e1.AddGroupingPolicy("alice", "admin")
+	assert.Nil(t, err)
+
+	changed, err := ReconcilePolicy(e2)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	ok, _ := e2.Enforce("admin", "/api/users", "DELETE")
+	assert.True(t, ok)
+	roles, _ := e2.GetRolesForUser("alice")
+	assert.Equal(t, []string{"admin"}, roles)
+
+	changed, err = ReconcilePolicy(e2)
+	assert.Nil(t, err)
+	assert.False(t, changed)
+
+	_, err = e1.RemoveGroupingPolicy("alice", "admin")
+	assert.Nil(t, err)
+	changed, err = ReconcilePolicy(e2)
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	roles, _ = e2.GetRolesForUser("alice")
+	assert.Empty(t, roles)
+
+	// the reconciled policies are not written to the database again
+	assert.Equal(t, 2, countRules(t, db))
+	policies, _ := e2.GetPolicy()
+	assert.Equal(t, PolicyChecksum(policies), PolicyChecksum([][]string{{"admin", "/api/users", "DELETE"},
+		{"editor", "/api/users", "GET"}}))
+}
+
+func TestPolicyChecksum(t *testing.T) {
+	assert.Equal(t, PolicyChecksum([][]string{{"a", "b"}, {"c", "d"}}), PolicyChecksum([][]string{{"c", "d"}, {"a", "b"}}))
+	assert.NotEqual(t, PolicyChecksum([][]string{{"a,b", "c"}}), PolicyChecksum([][]string{{"a", "b,c"}}))
+}
@@ -0,0 +1,35 @@
+package rocketmq
+
+import (
+	"context"
+	"encoding/json"
+
+	"mingyang.com/admin-common/orm/ent/history"
+)
+
+// HistorySink sends the entity history records to the topic, one message for each record.
+// The message tag is the entity type and the key is the entity ID.
+type HistorySink struct {
+	sender *Sender
+	topic  string
+}
+
+// NewHistorySink returns the history sink sending to the topic.
+func NewHistorySink(sender *Sender, topic string) *HistorySink {
+	return &HistorySink{sender: sender, topic: topic}
+}
+
+// Write sends the records synchronously.
+func (s *HistorySink) Write(ctx context.Context, records []*history.Record) error {
+	for _, v := range records {
+		body, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		if err = s.sender.SendSync(ctx, s.topic, body, WithTag(v.Entity), WithKeys([]string{v.EntityId})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
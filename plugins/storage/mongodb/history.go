@@ -0,0 +1,68 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"mingyang.com/admin-common/enum/common"
+	"mingyang.com/admin-common/orm/ent/history"
+)
+
+// DefaultHistoryCollection is the default collection name of HistorySink.
+const DefaultHistoryCollection = "entity_histories"
+
+// HistorySink writes the entity history records to MongoDB, and implements history.Reader.
+type HistorySink struct {
+	coll *mongo.Collection
+}
+
+// NewHistorySink returns the history sink of the collection, the default is DefaultHistoryCollection.
+func NewHistorySink(db *mongo.Database, collection string) *HistorySink {
+	if collection == common.EmptyString {
+		collection = DefaultHistoryCollection
+	}
+	return &HistorySink{coll: db.Collection(collection)}
+}
+
+// CreateIndexes creates the index for querying the history of an entity.
+func (s *HistorySink) CreateIndexes(ctx context.Context) error {
+	_, err := s.coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "entity", Value: 1}, {Key: "entityId", Value: 1}, {Key: "createdAt", Value: -1}},
+	})
+	return err
+}
+
+// Write inserts the records.
+func (s *HistorySink) Write(ctx context.Context, records []*history.Record) error {
+	if len(records) == common.Zero {
+		return nil
+	}
+
+	docs := make([]any, 0, len(records))
+	for _, v := range records {
+		docs = append(docs, v)
+	}
+	_, err := s.coll.InsertMany(ctx, docs)
+	return err
+}
+
+// List returns the history of the entity in the newest first order, limit <= 0 means no limit.
+func (s *HistorySink) List(ctx context.Context, entity, entityId string, limit int) ([]*history.Record, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := s.coll.Find(ctx, bson.D{{Key: "entity", Value: entity}, {Key: "entityId", Value: entityId}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*history.Record
+	if err = cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -67,3 +67,7 @@ func IsGrpcError(err error) bool {
 
 	return ok
 }
+
+// ErrVersionConflict is the error of the optimistic lock conflict, which means the data has been
+// modified by others after it was read. It is converted to http.StatusConflict by CodeFromGrpcError.
+var ErrVersionConflict = status.Error(codes.Aborted, "common.versionConflict")
@@ -135,3 +135,8 @@ func TestIsGrpcError(t *testing.T) {
 	assert.False(t, IsGrpcError(errors.New("foo")))
 	assert.False(t, IsGrpcError(nil))
 }
+
+func TestErrVersionConflict(t *testing.T) {
+	assert.Equal(t, codes.Aborted, status.Code(ErrVersionConflict))
+	assert.Equal(t, http.StatusConflict, CodeFromGrpcError(ErrVersionConflict))
+}
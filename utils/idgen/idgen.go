@@ -0,0 +1,54 @@
+package idgen
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid/v5"
+	"mingyang.com/admin-common/utils/sonyflake"
+)
+
+// IDGenerator generates the unique IDs of type T, it is used by the ID mixins to choose the strategy.
+//
+//	mixins.IDMixin{Generator: idgen.Sonyflake(nil)}
+//	mixins.IDStringMixin{Generator: idgen.ULID()}
+type IDGenerator[T any] interface {
+	NextID(ctx context.Context) (T, error)
+}
+
+// Func is the function implementing IDGenerator.
+type Func[T any] func(ctx context.Context) (T, error)
+
+func (f Func[T]) NextID(ctx context.Context) (T, error) {
+	return f(ctx)
+}
+
+// Map converts the IDs of the generator, such as the UUID to string.
+//
+//	idgen.Map(idgen.UUIDv7(), uuid.UUID.String)
+func Map[T, R any](g IDGenerator[T], fn func(T) R) IDGenerator[R] {
+	return Func[R](func(ctx context.Context) (R, error) {
+		v, err := g.NextID(ctx)
+		if err != nil {
+			var zero R
+			return zero, err
+		}
+		return fn(v), nil
+	})
+}
+
+// Sonyflake returns the generator of the snowflake, the default instance is used if sf is nil.
+func Sonyflake(sf *sonyflake.Snowflake) IDGenerator[uint64] {
+	return Func[uint64](func(ctx context.Context) (uint64, error) {
+		if sf == nil {
+			return sonyflake.NextIDE()
+		}
+		return sf.GenerateE()
+	})
+}
+
+// UUIDv7 returns the generator of the time-ordered UUID v7, which is the same as uuidx.NewUUID.
+func UUIDv7() IDGenerator[uuid.UUID] {
+	return Func[uuid.UUID](func(ctx context.Context) (uuid.UUID, error) {
+		return uuid.NewV7()
+	})
+}
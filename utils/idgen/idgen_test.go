@@ -0,0 +1,160 @@
+package idgen
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/gofrs/uuid/v5"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"mingyang.com/admin-common/utils/sonyflake"
+)
+
+func TestULID(t *testing.T) {
+	ctx := context.Background()
+	g := ULID()
+
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		id, err := g.NextID(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, id, 26)
+		assert.True(t, id[0] <= '7')
+		for _, c := range id {
+			assert.True(t, strings.ContainsRune(crockford, c))
+		}
+		ids = append(ids, id)
+	}
+	assert.True(t, sort.StringsAreSorted(ids))
+	for i := 1; i < len(ids); i++ {
+		assert.NotEqual(t, ids[i-1], ids[i])
+	}
+
+	// the first 48 bits are the timestamp
+	assert.Equal(t, "0000000000"+strings.Repeat("0", 16), encodeULID([16]byte{}))
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", encodeULID([16]byte{255, 255, 255, 255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255, 255, 255, 255}))
+}
+
+func TestMap(t *testing.T) {
+	g := Map(UUIDv7(), uuid.UUID.String)
+	id, err := g.NextID(context.Background())
+	assert.Nil(t, err)
+
+	v, err := uuid.FromString(id)
+	assert.Nil(t, err)
+	assert.Equal(t, byte(7), v.Version())
+
+	sf := sonyflake.MustSnowflake(12)
+	n, err := Map(Sonyflake(sf), func(v uint64) int64 { return int64(v) }).NextID(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(12), sonyflake.Decode(uint64(n)).WorkerId)
+}
+
+func TestSegment(t *testing.T) {
+	ctx := context.Background()
+	drv, err := entsql.Open(dialect.SQLite, "file:segment?mode=memory&cache=shared&_fk=1")
+	assert.Nil(t, err)
+	defer drv.Close()
+
+	store := NewSegmentStore(drv, "")
+	assert.Nil(t, store.CreateTable(ctx))
+
+	start, end, err := store.Allocate(ctx, "user", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), start)
+	assert.Equal(t, int64(10), end)
+
+	a := NewSegment[uint64](store, "user", 3)
+	b := NewSegment[uint64](store, "user", 3)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		seen = map[uint64]struct{}{}
+	)
+	for _, g := range []*Segment[uint64]{a, b} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last uint64
+			for i := 0; i < 20; i++ {
+				id, err := g.NextID(ctx)
+				assert.Nil(t, err)
+				assert.Greater(t, id, last)
+				assert.Greater(t, id, uint64(10))
+				last = id
+
+				mu.Lock()
+				_, ok := seen[id]
+				assert.False(t, ok, "duplicate id %d", id)
+				seen[id] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 40)
+
+	// the overflow of int32
+	_, err = drv.ExecContext(ctx, "UPDATE "+DefaultSegmentTable+" SET max_id = 2147483646 WHERE name = 'user'")
+	assert.Nil(t, err)
+	small := NewSegment[int32](store, "user", 2)
+	id, err := small.NextID(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2147483647), id)
+	_, err = small.NextID(ctx)
+	assert.Equal(t, ErrSegmentOverflow, err)
+
+	// the default store
+	_, err = NewSegment[int64](nil, "role", 0).NextID(ctx)
+	assert.NotNil(t, err)
+	SetDefaultSegmentStore(store)
+	defer SetDefaultSegmentStore(nil)
+	id64, err := NewSegment[int64](nil, "role", 0).NextID(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), id64)
+}
+
+func TestSegmentStoreConcurrentFirstUse(t *testing.T) {
+	ctx := context.Background()
+	drv, err := entsql.Open(dialect.SQLite, "file:"+filepath.Join(t.TempDir(), "segment.db")+"?_busy_timeout=5000&_fk=1")
+	assert.Nil(t, err)
+	defer drv.Close()
+
+	store := NewSegmentStore(drv, "")
+	assert.Nil(t, store.CreateTable(ctx))
+
+	// the allocations of a new name do not fail on the concurrent inserts
+	const workers, step = 8, 5
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		ends []int64
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start, end, err := store.Allocate(ctx, "order", step)
+			assert.Nil(t, err)
+			assert.Equal(t, int64(step), end-start)
+
+			mu.Lock()
+			ends = append(ends, end)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(ends, func(i, j int) bool { return ends[i] < ends[j] })
+	for i, end := range ends {
+		assert.Equal(t, int64((i+1)*step), end)
+	}
+}
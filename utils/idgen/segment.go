@@ -0,0 +1,169 @@
+package idgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"mingyang.com/admin-common/enum/common"
+)
+
+const (
+	// DefaultSegmentTable is the default table name of SegmentStore.
+	DefaultSegmentTable = "sys_id_segments"
+	// DefaultSegmentStep is the default count of IDs allocated each time.
+	DefaultSegmentStep = 1000
+)
+
+// ErrSegmentOverflow is returned if the allocated ID exceeds the range of the ID type.
+var ErrSegmentOverflow = errors.New("idgen: segment id overflows")
+
+var defaultSegmentStore atomic.Pointer[SegmentStore]
+
+// SetDefaultSegmentStore sets the store used by the segments created with nil store,
+// which allows the schemas to declare the segments before the database is opened.
+func SetDefaultSegmentStore(store *SegmentStore) {
+	defaultSegmentStore.Store(store)
+}
+
+// SegmentStore allocates the ID ranges by name from a table of the database.
+// The driver should not be the one of a transaction, so the allocated ranges are never rolled back.
+type SegmentStore struct {
+	drv   dialect.Driver
+	table string
+}
+
+// NewSegmentStore returns the store of the table, the default table is DefaultSegmentTable.
+// Call CreateTable to create the table if it is not managed by the migration.
+func NewSegmentStore(drv dialect.Driver, table string) *SegmentStore {
+	if table == common.EmptyString {
+		table = DefaultSegmentTable
+	}
+	return &SegmentStore{drv: drv, table: table}
+}
+
+// CreateTable creates the segment table if it does not exist.
+func (s *SegmentStore) CreateTable(ctx context.Context) error {
+	var stmt string
+	switch s.drv.Dialect() {
+	case dialect.MySQL:
+		stmt = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (name varchar(128) NOT NULL, max_id bigint NOT NULL, "+
+			"updated_at datetime(3) NOT NULL, PRIMARY KEY (name))", s.table)
+	case dialect.Postgres:
+		stmt = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (name varchar(128) PRIMARY KEY, max_id bigint NOT NULL, "+
+			"updated_at timestamp with time zone NOT NULL)", s.table)
+	case dialect.SQLite:
+		stmt = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (name text PRIMARY KEY, max_id integer NOT NULL, "+
+			"updated_at datetime NOT NULL)", s.table)
+	default:
+		return fmt.Errorf("unsupported dialect %q", s.drv.Dialect())
+	}
+	return s.drv.Exec(ctx, stmt, []any{}, nil)
+}
+
+// Allocate reserves step IDs of the name and returns the range (start, end], the first ID is 1.
+func (s *SegmentStore) Allocate(ctx context.Context, name string, step int64) (start, end int64, err error) {
+	if step <= 0 {
+		return 0, 0, fmt.Errorf("idgen: invalid segment step %d", step)
+	}
+
+	tx, err := s.drv.Tx(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// the row of the name is created or increased in one statement, which is safe for the concurrent first use
+	b := entsql.Dialect(s.drv.Dialect())
+	query, args := b.Insert(s.table).Columns("name", "max_id", "updated_at").Values(name, step, time.Now()).
+		OnConflict(entsql.ConflictColumns("name"), entsql.ResolveWith(func(u *entsql.UpdateSet) {
+			u.Add("max_id", step)
+			u.SetExcluded("updated_at")
+		})).Query()
+	if err = tx.Exec(ctx, query, args, nil); err != nil {
+		return 0, 0, err
+	}
+
+	query, args = b.Select("max_id").From(entsql.Table(s.table)).Where(entsql.EQ("name", name)).Query()
+	rows := &entsql.Rows{}
+	if err = tx.Query(ctx, query, args, rows); err != nil {
+		return 0, 0, err
+	}
+	if err = entsql.ScanOne(rows, &end); err != nil {
+		_ = rows.Close()
+		return 0, 0, err
+	}
+	if err = rows.Close(); err != nil {
+		return 0, 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return end - step, end, nil
+}
+
+// Integer is the numeric ID types supported by Segment.
+type Integer interface {
+	~int32 | ~int64 | ~uint32 | ~uint64
+}
+
+// Segment generates the monotonic numeric IDs from the ranges allocated by the store, a new range
+// is allocated when the current one is used up. The IDs are increasing in one process, and the IDs
+// of different processes are unique but interleaved by ranges.
+type Segment[T Integer] struct {
+	store *SegmentStore
+	name  string
+	step  int64
+
+	mu   sync.Mutex
+	next int64
+	end  int64
+}
+
+// NewSegment returns the segment generator of the name, such as the table name. The default store
+// set by SetDefaultSegmentStore is used if store is nil, and DefaultSegmentStep is used if step <= 0.
+func NewSegment[T Integer](store *SegmentStore, name string, step int64) *Segment[T] {
+	if step <= 0 {
+		step = DefaultSegmentStep
+	}
+	return &Segment[T]{store: store, name: name, step: step}
+}
+
+func (s *Segment[T]) NextID(ctx context.Context) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next >= s.end {
+		store := s.store
+		if store == nil {
+			store = defaultSegmentStore.Load()
+		}
+		if store == nil {
+			return 0, errors.New("idgen: segment store is not set")
+		}
+
+		start, end, err := store.Allocate(ctx, s.name, s.step)
+		if err != nil {
+			return 0, err
+		}
+		s.next, s.end = start, end
+	}
+
+	s.next++
+	id := T(s.next)
+	if int64(id) != s.next || id <= 0 {
+		s.next--
+		return 0, ErrSegmentOverflow
+	}
+	return id, nil
+}
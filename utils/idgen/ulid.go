@@ -0,0 +1,90 @@
+package idgen
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford's base32 alphabet of ULID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ErrULIDOverflow is returned if the random part overflows in the same millisecond.
+var ErrULIDOverflow = errors.New("idgen: ulid random part overflows")
+
+var defaultULID = &ULIDGenerator{}
+
+// ULID returns the generator of the monotonic ULID, which is a 26 characters string sorted by time.
+func ULID() IDGenerator[string] {
+	return defaultULID
+}
+
+// NewULID returns a new monotonic ULID.
+func NewULID() (string, error) {
+	return defaultULID.NextID(context.Background())
+}
+
+// ULIDGenerator generates the ULIDs, the random part is incremented in the same millisecond
+// to keep the IDs monotonic.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	lastRnd [10]byte
+}
+
+func (g *ULIDGenerator) NextID(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(time.Now().UnixMilli())
+	if ms <= g.lastMs {
+		// 同一毫秒或时钟回拨时沿用上次时间, 随机部分加一
+		ms = g.lastMs
+		if !increment(g.lastRnd[:]) {
+			return "", ErrULIDOverflow
+		}
+	} else {
+		if _, err := rand.Read(g.lastRnd[:]); err != nil {
+			return "", err
+		}
+		g.lastMs = ms
+	}
+
+	var id [16]byte
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+	copy(id[6:], g.lastRnd[:])
+	return encodeULID(id), nil
+}
+
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeULID encodes the 128 bits to 26 base32 characters, the first character holds 3 bits.
+func encodeULID(id [16]byte) string {
+	var dst [26]byte
+	// 130 bits with 2 leading zero bits
+	var acc uint32
+	bits := 2
+	n := 0
+	for _, v := range id {
+		acc = acc<<8 | uint32(v)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			dst[n] = crockford[(acc>>bits)&0x1f]
+			n++
+		}
+	}
+	return string(dst[:])
+}
@@ -0,0 +1,294 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/zeromicro/go-zero/core/logx"
+	"golang.org/x/sync/singleflight"
+)
+
+// JWK is a JSON Web Key which holds a public key, see RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set document.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK returns the public JWK of the key. HMAC keys cannot be published.
+func NewJWK(key *Key) (JWK, error) {
+	jwk := JWK{
+		Kid: key.ID,
+		Use: "sig",
+		Alg: key.Method.Alg(),
+	}
+
+	switch pub := key.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		data, err := pub.Bytes()
+		if err != nil {
+			return JWK{}, err
+		}
+		size := (len(data) - 1) / 2
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(data[1 : 1+size])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(data[1+size:])
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JWK{}, fmt.Errorf("key %q cannot be published in JWKS", key.ID)
+	}
+
+	return jwk, nil
+}
+
+// Key converts the JWK to a verification key.
+func (j JWK) Key() (*Key, error) {
+	method := jwt.GetSigningMethod(j.Alg)
+
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid RSA modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid RSA exponent")
+		}
+		if method == nil {
+			method = jwt.SigningMethodRS256
+		}
+		return NewVerificationKey(j.Kid, method, &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}), nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported elliptic curve: %s", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid EC x coordinate")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid EC y coordinate")
+		}
+		pub, err := ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return nil, err
+		}
+		if method == nil {
+			if method, err = ecdsaMethod(curve); err != nil {
+				return nil, err
+			}
+		}
+		return NewVerificationKey(j.Kid, method, pub), nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve: %s", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+		return NewVerificationKey(j.Kid, jwt.SigningMethodEdDSA, ed25519.PublicKey(x)), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", j.Kty)
+	}
+}
+
+// JWKS returns the document of all public keys in the set. HMAC keys are skipped.
+func (s *KeySet) JWKS() JWKS {
+	doc := JWKS{Keys: []JWK{}}
+	for _, v := range s.Keys() {
+		jwk, err := NewJWK(v)
+		if err != nil {
+			continue
+		}
+		doc.Keys = append(doc.Keys, jwk)
+	}
+
+	return doc
+}
+
+// ParseJWKS parses the JWKS document to a verification key set. Unsupported keys are skipped.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var doc JWKS
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "invalid JWKS document")
+	}
+
+	var keys []*Key
+	for _, v := range doc.Keys {
+		if v.Use != "" && v.Use != "sig" {
+			continue
+		}
+		key, err := v.Key()
+		if err != nil {
+			logx.Errorw("skip invalid JWK", logx.Field("kid", v.Kid), logx.Field("detail", err.Error()))
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	return NewVerificationKeySet(keys...)
+}
+
+// JWKSHandler returns the HTTP handler which serves the public keys of the set.
+func JWKSHandler(s *KeySet) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		if err := json.NewEncoder(w).Encode(s.JWKS()); err != nil {
+			logx.Errorw("failed to write JWKS", logx.Field("detail", err.Error()))
+		}
+	}
+}
+
+// RemoteKeySet is a verification-only KeyProvider which loads keys from a JWKS
+// URL. Keys are refreshed periodically and whenever an unknown kid is seen, so
+// the issuer can rotate keys without redeploying the verifiers.
+type RemoteKeySet struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+	minInterval     time.Duration
+
+	mu          sync.RWMutex
+	keys        *KeySet
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	group       singleflight.Group
+}
+
+// NewRemoteKeySet returns the key set loaded from the JWKS url.
+func NewRemoteKeySet(url string, refreshInterval time.Duration) *RemoteKeySet {
+	if refreshInterval <= 0 {
+		refreshInterval = 10 * time.Minute
+	}
+
+	return &RemoteKeySet{
+		url:             url,
+		client:          &http.Client{Timeout: 5 * time.Second},
+		refreshInterval: refreshInterval,
+		minInterval:     10 * time.Second,
+	}
+}
+
+// SigningKey always returns ErrNoSigningKey because remote keys only contain public keys.
+func (r *RemoteKeySet) SigningKey() (*Key, error) {
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey returns the key by kid, refreshing the remote keys if needed.
+func (r *RemoteKeySet) VerificationKey(kid string) (*Key, error) {
+	r.mu.RLock()
+	keys, fetchedAt, attemptedAt := r.keys, r.fetchedAt, r.attemptedAt
+	r.mu.RUnlock()
+
+	if keys != nil && time.Since(fetchedAt) <= r.refreshInterval {
+		if key, err := keys.VerificationKey(kid); err == nil {
+			return key, nil
+		}
+	}
+
+	// the keys are stale or the issuer may have rotated to a new key
+	if time.Since(attemptedAt) > r.minInterval {
+		if err := r.Refresh(context.Background()); err != nil {
+			logx.Errorw("failed to refresh JWKS", logx.Field("url", r.url), logx.Field("detail", err.Error()))
+		}
+	}
+
+	r.mu.RLock()
+	keys = r.keys
+	r.mu.RUnlock()
+
+	if keys == nil {
+		return nil, ErrKeyNotFound
+	}
+
+	return keys.VerificationKey(kid)
+}
+
+// Refresh loads the keys from the JWKS url.
+func (r *RemoteKeySet) Refresh(ctx context.Context) error {
+	_, err, _ := r.group.Do(r.url, func() (any, error) {
+		r.mu.Lock()
+		r.attemptedAt = time.Now()
+		r.mu.Unlock()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch JWKS")
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch JWKS, status code: %d", resp.StatusCode)
+		}
+
+		var doc json.RawMessage
+		if err = json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+			return nil, errors.Wrap(err, "invalid JWKS document")
+		}
+
+		keys, err := ParseJWKS(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		r.mu.Lock()
+		r.keys = keys
+		r.fetchedAt = time.Now()
+		r.mu.Unlock()
+
+		return nil, nil
+	})
+
+	return err
+}
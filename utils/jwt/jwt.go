@@ -50,6 +50,30 @@ func NewJwtToken(secretKey string, iat, seconds int64, opt ...Option) (string, e
 	return token.SignedString([]byte(secretKey))
 }
 
+// NewJwtTokenWithProvider returns the jwt token signed by the signing key of the provider.
+// The kid of the key is written to the token header.
+func NewJwtTokenWithProvider(provider KeyProvider, iat, seconds int64, opt ...Option) (string, error) {
+	key, err := provider.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	claims := make(jwt.MapClaims)
+	claims["exp"] = iat + seconds
+	claims["iat"] = iat
+
+	for _, v := range opt {
+		claims[v.Key] = v.Val
+	}
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.Private)
+}
+
+// ParseJwtToken parses the HS256 token signed by the secret key.
 func ParseJwtToken(tokenStr, secretKey string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -66,6 +90,30 @@ func ParseJwtToken(tokenStr, secretKey string) (jwt.MapClaims, error) {
 	return nil, errors.New("invalid token")
 }
 
+// ParseJwtTokenWithProvider parses the token with the verification key matching its kid.
+// The algorithm of the token must be the same as the algorithm of the key.
+// Numeric claims are decoded as json.Number so that 64-bit ids keep their precision.
+func ParseJwtTokenWithProvider(tokenStr string, provider KeyProvider) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, err := provider.VerificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		if t.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return key.Public, nil
+	}, jwt.WithJSONNumber())
+	if err != nil {
+		return nil, err
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
 func MapClaimsToStruct(claims jwt.MapClaims, out interface{}) error {
 	jsonData, err := json.Marshal(claims)
 	if err != nil {
@@ -0,0 +1,329 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrKeyNotFound is returned when no verification key matches the kid of a token.
+	ErrKeyNotFound = errors.New("jwt key not found")
+
+	// ErrNoSigningKey is returned when the provider can only verify tokens.
+	ErrNoSigningKey = errors.New("jwt signing key is not configured")
+)
+
+// Key is a signing or verification key identified by its kid.
+type Key struct {
+	// ID is written to the "kid" header of signed tokens. Empty ID means no kid header.
+	ID string
+	// Method is the signing algorithm of the key.
+	Method jwt.SigningMethod
+	// Private is used to sign tokens. It is nil for verification-only keys.
+	Private any
+	// Public is used to verify tokens.
+	Public any
+}
+
+// CanSign returns true if the key holds the private part.
+func (k *Key) CanSign() bool {
+	return k.Private != nil
+}
+
+// KeyProvider supplies the keys used to sign and verify tokens.
+type KeyProvider interface {
+	// SigningKey returns the key used to sign new tokens.
+	SigningKey() (*Key, error)
+	// VerificationKey returns the key matching the kid in the token header.
+	VerificationKey(kid string) (*Key, error)
+}
+
+// NewHMACKey returns a HS256 key from the secret.
+func NewHMACKey(kid, secret string) *Key {
+	return &Key{
+		ID:      kid,
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}
+}
+
+// NewRSAKey returns a RS256 key from the RSA private key.
+func NewRSAKey(kid string, key *rsa.PrivateKey) *Key {
+	return &Key{
+		ID:      kid,
+		Method:  jwt.SigningMethodRS256,
+		Private: key,
+		Public:  &key.PublicKey,
+	}
+}
+
+// NewECDSAKey returns an ECDSA key, the algorithm is chosen by the curve of the key.
+func NewECDSAKey(kid string, key *ecdsa.PrivateKey) (*Key, error) {
+	method, err := ecdsaMethod(key.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{
+		ID:      kid,
+		Method:  method,
+		Private: key,
+		Public:  &key.PublicKey,
+	}, nil
+}
+
+// NewEd25519Key returns an EdDSA key from the Ed25519 private key.
+func NewEd25519Key(kid string, key ed25519.PrivateKey) *Key {
+	return &Key{
+		ID:      kid,
+		Method:  jwt.SigningMethodEdDSA,
+		Private: key,
+		Public:  key.Public(),
+	}
+}
+
+// NewVerificationKey returns a key which can only be used to verify tokens.
+func NewVerificationKey(kid string, method jwt.SigningMethod, public any) *Key {
+	return &Key{
+		ID:     kid,
+		Method: method,
+		Public: public,
+	}
+}
+
+// GenerateKey generates a new key for the algorithm. Supported algorithms are RS256, ES256 and EdDSA.
+func GenerateKey(kid, alg string) (*Key, error) {
+	switch alg {
+	case jwt.SigningMethodRS256.Alg():
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return NewRSAKey(kid, key), nil
+	case jwt.SigningMethodES256.Alg():
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewECDSAKey(kid, key)
+	case jwt.SigningMethodEdDSA.Alg():
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewEd25519Key(kid, key), nil
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", alg)
+	}
+}
+
+// ParsePrivateKeyFromPEM parses a PEM encoded RSA, ECDSA or Ed25519 private key.
+func ParsePrivateKeyFromPEM(kid string, data []byte) (*Key, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return NewRSAKey(kid, key), nil
+	}
+
+	if key, err := jwt.ParseECPrivateKeyFromPEM(data); err == nil {
+		return NewECDSAKey(kid, key)
+	}
+
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		if edKey, ok := key.(ed25519.PrivateKey); ok {
+			return NewEd25519Key(kid, edKey), nil
+		}
+	}
+
+	return nil, errors.New("unsupported private key, only RSA, ECDSA and Ed25519 keys are supported")
+}
+
+// ParsePublicKeyFromPEM parses a PEM encoded RSA, ECDSA or Ed25519 public key as a verification key.
+func ParsePublicKeyFromPEM(kid string, data []byte) (*Key, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return NewVerificationKey(kid, jwt.SigningMethodRS256, key), nil
+	}
+
+	if key, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+		method, err := ecdsaMethod(key.Curve)
+		if err != nil {
+			return nil, err
+		}
+		return NewVerificationKey(kid, method, key), nil
+	}
+
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return NewVerificationKey(kid, jwt.SigningMethodEdDSA, key), nil
+	}
+
+	return nil, errors.New("unsupported public key, only RSA, ECDSA and Ed25519 keys are supported")
+}
+
+func ecdsaMethod(curve elliptic.Curve) (jwt.SigningMethod, error) {
+	switch curve {
+	case elliptic.P256():
+		return jwt.SigningMethodES256, nil
+	case elliptic.P384():
+		return jwt.SigningMethodES384, nil
+	case elliptic.P521():
+		return jwt.SigningMethodES512, nil
+	default:
+		return nil, errors.New("unsupported elliptic curve")
+	}
+}
+
+// KeySet is an in-memory KeyProvider which holds one active signing key and
+// any number of verification keys. It is safe for concurrent use, so keys can
+// be rotated while the service is running.
+type KeySet struct {
+	mu     sync.RWMutex
+	active string
+	keys   map[string]*Key
+	order  []string
+}
+
+// NewKeySet returns a key set which signs with the active key and verifies
+// tokens signed by the active key or any of the others.
+func NewKeySet(active *Key, others ...*Key) (*KeySet, error) {
+	s := &KeySet{keys: make(map[string]*Key)}
+	if err := s.Rotate(active); err != nil {
+		return nil, err
+	}
+
+	for _, v := range others {
+		if err := s.Add(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// NewVerificationKeySet returns a key set which can only verify tokens.
+func NewVerificationKeySet(keys ...*Key) (*KeySet, error) {
+	s := &KeySet{keys: make(map[string]*Key)}
+	for _, v := range keys {
+		if err := s.Add(v); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// NewHMACKeyProvider returns the HS256 provider with the shared secret. The
+// tokens signed by it carry no kid header, which is compatible with NewJwtToken.
+func NewHMACKeyProvider(secretKey string) *KeySet {
+	s, _ := NewKeySet(NewHMACKey("", secretKey))
+	return s
+}
+
+// SigningKey returns the active key.
+func (s *KeySet) SigningKey() (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[s.active]
+	if !ok || !key.CanSign() {
+		return nil, ErrNoSigningKey
+	}
+
+	return key, nil
+}
+
+// VerificationKey returns the key by kid. Tokens without kid are verified by the active key.
+func (s *KeySet) VerificationKey(kid string) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	if kid == "" {
+		if key, ok := s.keys[s.active]; ok {
+			return key, nil
+		}
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// Add adds a verification key. The key replaces the existing key with the same kid.
+func (s *KeySet) Add(key *Key) error {
+	if key == nil || key.Method == nil || key.Public == nil {
+		return errors.New("invalid jwt key")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key.ID]; !ok {
+		s.order = append(s.order, key.ID)
+	}
+	s.keys[key.ID] = key
+
+	return nil
+}
+
+// Rotate makes the key the active signing key. The previous active key is kept
+// for verification until it is removed, so issued tokens stay valid.
+func (s *KeySet) Rotate(key *Key) error {
+	if key == nil || !key.CanSign() {
+		return ErrNoSigningKey
+	}
+
+	if err := s.Add(key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.active = key.ID
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Remove removes the verification key by kid. The active key cannot be removed.
+func (s *KeySet) Remove(kid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[kid]; !ok {
+		return ErrKeyNotFound
+	}
+
+	if kid == s.active && s.keys[kid].CanSign() {
+		return errors.New("the active signing key cannot be removed")
+	}
+
+	delete(s.keys, kid)
+	for i, v := range s.order {
+		if v == kid {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// Keys returns all keys in the order they were added.
+func (s *KeySet) Keys() []*Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]*Key, 0, len(s.order))
+	for _, v := range s.order {
+		keys = append(keys, s.keys[v])
+	}
+
+	return keys
+}
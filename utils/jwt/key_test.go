@@ -0,0 +1,135 @@
+package jwt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJwtTokenWithProvider(t *testing.T) {
+	for _, alg := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			key, err := GenerateKey("key-1", alg)
+			assert.Nil(t, err)
+
+			provider, err := NewKeySet(key)
+			assert.Nil(t, err)
+
+			token, err := NewJwtTokenWithProvider(provider, time.Now().Unix(), 60, WithOption("userId", "abc"))
+			assert.Nil(t, err)
+
+			claims, err := ParseJwtTokenWithProvider(token, provider)
+			assert.Nil(t, err)
+			assert.Equal(t, "abc", claims["userId"])
+		})
+	}
+}
+
+func TestHMACKeyProviderCompatible(t *testing.T) {
+	token, err := NewJwtTokenWithProvider(NewHMACKeyProvider("jS6VKDtsJf3z1n2VKDtsJf3z1n2"), 1000, 10,
+		WithOption("userId", "abc"), WithOption("roleId", 1))
+	assert.Nil(t, err)
+
+	legacy, err := NewJwtToken("jS6VKDtsJf3z1n2VKDtsJf3z1n2", 1000, 10,
+		WithOption("userId", "abc"), WithOption("roleId", 1))
+	assert.Nil(t, err)
+	assert.Equal(t, legacy, token)
+}
+
+func TestKeySetRotate(t *testing.T) {
+	oldKey, _ := GenerateKey("old", "ES256")
+	newKey, _ := GenerateKey("new", "EdDSA")
+
+	provider, err := NewKeySet(oldKey)
+	assert.Nil(t, err)
+
+	oldToken, err := NewJwtTokenWithProvider(provider, time.Now().Unix(), 60)
+	assert.Nil(t, err)
+
+	assert.Nil(t, provider.Rotate(newKey))
+	newToken, err := NewJwtTokenWithProvider(provider, time.Now().Unix(), 60)
+	assert.Nil(t, err)
+
+	_, err = ParseJwtTokenWithProvider(oldToken, provider)
+	assert.Nil(t, err)
+	_, err = ParseJwtTokenWithProvider(newToken, provider)
+	assert.Nil(t, err)
+
+	assert.NotNil(t, provider.Remove("new"))
+	assert.Nil(t, provider.Remove("old"))
+	_, err = ParseJwtTokenWithProvider(oldToken, provider)
+	assert.NotNil(t, err)
+}
+
+func TestParseJwtTokenWithProviderAlgorithm(t *testing.T) {
+	rsaKey, _ := GenerateKey("key-1", "RS256")
+	provider, _ := NewKeySet(rsaKey)
+
+	// a HS256 token signed by the public key must be rejected
+	forged, err := NewJwtTokenWithProvider(NewHMACKeyProvider("key-1"), time.Now().Unix(), 60)
+	assert.Nil(t, err)
+	_, err = ParseJwtTokenWithProvider(forged, provider)
+	assert.NotNil(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, _ := GenerateKey("rsa", "RS256")
+	ecKey, _ := GenerateKey("ec", "ES256")
+	edKey, _ := GenerateKey("ed", "EdDSA")
+
+	provider, err := NewKeySet(rsaKey, ecKey, edKey, NewHMACKey("hmac", "secret"))
+	assert.Nil(t, err)
+
+	data, err := json.Marshal(provider.JWKS())
+	assert.Nil(t, err)
+
+	verifier, err := ParseJWKS(data)
+	assert.Nil(t, err)
+	assert.Len(t, verifier.Keys(), 3)
+
+	_, err = verifier.SigningKey()
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+
+	for _, key := range []*Key{rsaKey, ecKey, edKey} {
+		signer, _ := NewKeySet(key)
+		token, err := NewJwtTokenWithProvider(signer, time.Now().Unix(), 60)
+		assert.Nil(t, err)
+
+		_, err = ParseJwtTokenWithProvider(token, verifier)
+		assert.Nil(t, err)
+	}
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	oldKey, _ := GenerateKey("old", "ES256")
+	provider, _ := NewKeySet(oldKey)
+
+	server := httptest.NewServer(JWKSHandler(provider))
+	defer server.Close()
+
+	remote := NewRemoteKeySet(server.URL, time.Minute)
+	remote.minInterval = 0
+
+	token, _ := NewJwtTokenWithProvider(provider, time.Now().Unix(), 60)
+	_, err := ParseJwtTokenWithProvider(token, remote)
+	assert.Nil(t, err)
+
+	// the remote key set reloads the keys when it meets an unknown kid
+	newKey, _ := GenerateKey("new", "RS256")
+	assert.Nil(t, provider.Rotate(newKey))
+	token, _ = NewJwtTokenWithProvider(provider, time.Now().Unix(), 60)
+	_, err = ParseJwtTokenWithProvider(token, remote)
+	assert.Nil(t, err)
+
+	_, err = remote.SigningKey()
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+
+	resp, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	_ = resp.Body.Close()
+}
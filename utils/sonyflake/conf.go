@@ -0,0 +1,82 @@
+package sonyflake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// WorkerIdSourceStatic 使用配置的 WorkerId
+	WorkerIdSourceStatic = "static"
+	// WorkerIdSourceEnv 从 WorkerIdEnv 指定的环境变量读取
+	WorkerIdSourceEnv = "env"
+	// WorkerIdSourceRedis 通过 Redis 租约分配
+	WorkerIdSourceRedis = "redis"
+)
+
+// Conf is the sonyflake configuration structure
+type Conf struct {
+	WorkerIdSource  string         `json:",optional,default=static,options=[static,env,redis],env=SONYFLAKE_WORKER_ID_SOURCE"` // worker id source
+	WorkerId        int64          `json:",optional,default=0,env=SONYFLAKE_WORKER_ID"`                                        // worker id of static source
+	WorkerIdEnv     string         `json:",optional,default=POD_NAME,env=SONYFLAKE_WORKER_ID_ENV"`                             // env name of env source, such as the pod name app-3
+	LeaseTTL        time.Duration  `json:",optional,default=30s,env=SONYFLAKE_LEASE_TTL"`                                      // lease ttl of redis source
+	RollbackPolicy  RollbackPolicy `json:",optional,default=wait,options=[wait,error],env=SONYFLAKE_ROLLBACK_POLICY"`          // clock rollback policy
+	MaxRollbackWait time.Duration  `json:",optional,default=1s,env=SONYFLAKE_MAX_ROLLBACK_WAIT"`                               // max wait time of wait policy
+}
+
+// NewSnowflake 根据配置创建实例, rds 仅在 redis 来源时使用, 退出前调用 Close 释放租约
+func (c Conf) NewSnowflake(rds redis.UniversalClient) (*Snowflake, error) {
+	policy := c.RollbackPolicy
+	if policy == "" {
+		policy = RollbackWait
+	}
+	opt := WithRollbackPolicy(policy, c.MaxRollbackWait)
+
+	switch c.WorkerIdSource {
+	case "", WorkerIdSourceStatic:
+		return NewSnowflake(c.WorkerId, opt)
+	case WorkerIdSourceEnv:
+		id, err := WorkerIdFromEnv(c.WorkerIdEnv)
+		if err != nil {
+			return nil, err
+		}
+		return NewSnowflake(id, opt)
+	case WorkerIdSourceRedis:
+		if rds == nil {
+			return nil, fmt.Errorf("sonyflake: redis is required by the worker id source %s", c.WorkerIdSource)
+		}
+
+		allocator := NewRedisWorkerAllocator(rds, c.LeaseTTL)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		id, err := allocator.Acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sf, err := NewSnowflake(id, opt)
+		if err != nil {
+			_ = allocator.Close()
+			return nil, err
+		}
+		sf.allocator = allocator
+		allocator.KeepAlive(sf.setWorker)
+		return sf, nil
+	default:
+		return nil, fmt.Errorf("sonyflake: unsupported worker id source %s", c.WorkerIdSource)
+	}
+}
+
+// MustSetup 根据配置创建实例并设置为默认实例, 出错时退出
+func (c Conf) MustSetup(rds redis.UniversalClient) *Snowflake {
+	sf, err := c.NewSnowflake(rds)
+	logx.Must(err)
+
+	SetDefault(sf)
+	return sf
+}
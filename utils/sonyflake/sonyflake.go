@@ -2,7 +2,9 @@ package sonyflake
 
 import (
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -17,69 +19,170 @@ const (
 	workId         = int64(0)
 )
 
+// DefaultMaxRollbackWait 时钟回拨时默认的最大等待时间
+const DefaultMaxRollbackWait = time.Second
+
+// ErrClockRollback 时钟回拨且无法等待时钟追上时返回
+var ErrClockRollback = errors.New("sonyflake: clock moved backwards")
+
+// RollbackPolicy 时钟回拨的处理策略
+type RollbackPolicy string
+
+const (
+	// RollbackWait 等待时钟追上上次生成的时间戳, 回拨超过最大等待时间时返回 ErrClockRollback
+	RollbackWait RollbackPolicy = "wait"
+	// RollbackError 直接返回 ErrClockRollback
+	RollbackError RollbackPolicy = "error"
+)
+
 // A Snowflake struct holds the basic information needed for a snowflake generator worker
 type Snowflake struct {
 	sync.Mutex
 	timestamp int64
 	workerid  int64
 	sequence  int64
+
+	policy  RollbackPolicy
+	maxWait time.Duration
+	// workerErr 机器 ID 不可用的原因, 如 Redis 租约丢失
+	workerErr error
+	allocator *RedisWorkerAllocator
+}
+
+// Option 创建 Snowflake 的选项
+type Option func(s *Snowflake)
+
+// WithRollbackPolicy 设置时钟回拨的处理策略, maxWait 仅对 RollbackWait 有效, 默认 DefaultMaxRollbackWait
+func WithRollbackPolicy(policy RollbackPolicy, maxWait time.Duration) Option {
+	return func(s *Snowflake) {
+		s.policy = policy
+		if maxWait > 0 {
+			s.maxWait = maxWait
+		}
+	}
 }
 
 // 全局默认实例
-var defaultSnowflake *Snowflake
+var defaultSnowflake atomic.Pointer[Snowflake]
 
-// 初始化默认实例
+// 初始化默认实例, 多副本部署时需通过 SetDefault 或 Conf.MustSetup 设置各自的机器 ID
 func init() {
 	sf, _ := NewSnowflake(workId)
-	defaultSnowflake = sf
+	defaultSnowflake.Store(sf)
+}
+
+// SetDefault 替换 NextID 等便捷函数使用的默认实例
+func SetDefault(sf *Snowflake) {
+	defaultSnowflake.Store(sf)
+}
+
+// Default 返回默认实例
+func Default() *Snowflake {
+	return defaultSnowflake.Load()
 }
 
 // NextID 便捷函数，直接生成单个ID
 func NextID() uint64 {
-	return defaultSnowflake.Generate()
+	return Default().Generate()
 }
 
 // BatchNextID 便捷函数，批量生成ID
 func BatchNextID(count int) []uint64 {
-	return defaultSnowflake.BatchGenerate(count)
+	return Default().BatchGenerate(count)
+}
+
+// NextIDE 便捷函数，生成单个ID并返回错误
+func NextIDE() (uint64, error) {
+	return Default().GenerateE()
+}
+
+// BatchNextIDE 便捷函数，批量生成ID并返回错误
+func BatchNextIDE(count int) ([]uint64, error) {
+	return Default().BatchGenerateE(count)
 }
 
 // NewSnowflake NewNode returns a new snowflake worker that can be used to generate snowflake IDs
-func NewSnowflake(workerid int64) (*Snowflake, error) {
+func NewSnowflake(workerid int64, opts ...Option) (*Snowflake, error) {
 	if workerid < 0 || workerid > workeridMax {
 		return nil, errors.New("workerid must be between 0 and 1023")
 	}
 
-	return &Snowflake{
+	s := &Snowflake{
 		timestamp: 0,
 		workerid:  workerid,
 		sequence:  0,
-	}, nil
+		policy:    RollbackWait,
+		maxWait:   DefaultMaxRollbackWait,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 // MustSnowflake 创建Snowflake实例，如果出错则panic
-func MustSnowflake(workerid int64) *Snowflake {
-	sf, err := NewSnowflake(workerid)
+func MustSnowflake(workerid int64, opts ...Option) *Snowflake {
+	sf, err := NewSnowflake(workerid, opts...)
 	if err != nil {
 		panic(err)
 	}
 	return sf
 }
 
-// Generate creates and returns a unique snowflake ID
+// WorkerId 返回当前的机器 ID, Redis 租约重新分配后会变化
+func (s *Snowflake) WorkerId() int64 {
+	s.Lock()
+	defer s.Unlock()
+	return s.workerid
+}
+
+// Close 释放 Redis 分配的机器 ID 租约, 之后不能再生成 ID
+func (s *Snowflake) Close() error {
+	if s.allocator == nil {
+		return nil
+	}
+
+	err := s.allocator.Close()
+	s.setWorker(-1, ErrWorkerLeaseLost)
+	return err
+}
+
+// setWorker 更新机器 ID, err 不为空时停止生成 ID
+func (s *Snowflake) setWorker(workerid int64, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if err == nil {
+		s.workerid = workerid
+	}
+	s.workerErr = err
+}
+
+// Generate creates and returns a unique snowflake ID, it panics if GenerateE returns an error
 func (s *Snowflake) Generate() uint64 {
+	id, err := s.GenerateE()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// GenerateE creates and returns a unique snowflake ID, the clock rollback is handled by the RollbackPolicy,
+// and ErrWorkerLeaseLost is returned if the worker id lease is lost
+func (s *Snowflake) GenerateE() (uint64, error) {
 	s.Lock()
 	defer s.Unlock()
 
-	now := time.Now().UnixNano() / 1000000
+	now, err := s.now()
+	if err != nil {
+		return 0, err
+	}
 
 	if s.timestamp == now {
 		s.sequence = (s.sequence + 1) & sequenceMask
 
 		if s.sequence == 0 {
-			for now <= s.timestamp {
-				now = time.Now().UnixNano() / 1000000
-			}
+			now = s.waitNextMillis()
 		}
 	} else {
 		s.sequence = 0
@@ -87,21 +190,33 @@ func (s *Snowflake) Generate() uint64 {
 
 	s.timestamp = now
 
-	r := uint64((now-twepoch)<<timestampShift | (s.workerid << workeridShift) | (s.sequence))
-	return r
+	return s.compose(now), nil
 }
 
-// BatchGenerate 批量生成唯一snowflake ID
+// BatchGenerate 批量生成唯一snowflake ID, BatchGenerateE 返回错误时 panic
 func (s *Snowflake) BatchGenerate(count int) []uint64 {
+	ids, err := s.BatchGenerateE(count)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// BatchGenerateE 批量生成唯一snowflake ID, 时钟回拨按 RollbackPolicy 处理, 机器 ID 租约丢失时返回 ErrWorkerLeaseLost
+func (s *Snowflake) BatchGenerateE(count int) ([]uint64, error) {
 	if count <= 0 {
-		return []uint64{}
+		return []uint64{}, nil
 	}
 
 	s.Lock()
 	defer s.Unlock()
 
+	now, err := s.now()
+	if err != nil {
+		return nil, err
+	}
+
 	ids := make([]uint64, count)
-	now := time.Now().UnixNano() / 1000000
 
 	// 统一处理逻辑
 	if s.timestamp != now {
@@ -113,13 +228,73 @@ func (s *Snowflake) BatchGenerate(count int) []uint64 {
 		s.sequence = (s.sequence + 1) & sequenceMask
 		if s.sequence == 0 {
 			// 序列号用完，等待下一毫秒
-			for now <= s.timestamp {
-				now = time.Now().UnixNano() / 1000000
-			}
+			now = s.waitNextMillis()
 			s.timestamp = now
 		}
-		ids[i] = uint64((now-twepoch)<<timestampShift | (s.workerid << workeridShift) | (s.sequence))
+		ids[i] = s.compose(now)
 	}
 
-	return ids
+	return ids, nil
+}
+
+// now 返回不早于上次生成时间的当前毫秒数, 需持有锁
+func (s *Snowflake) now() (int64, error) {
+	if s.workerErr != nil {
+		return 0, s.workerErr
+	}
+	// 续约每 1/3 租约时长才执行一次, 生成前检查租约是否已过期
+	if s.allocator != nil && !s.allocator.holds(s.workerid) {
+		return 0, ErrWorkerLeaseLost
+	}
+
+	now := currentMillis()
+	if now >= s.timestamp {
+		return now, nil
+	}
+
+	backward := time.Duration(s.timestamp-now) * time.Millisecond
+	if s.policy == RollbackError || backward > s.maxWait {
+		return 0, fmt.Errorf("%w by %s", ErrClockRollback, backward)
+	}
+
+	// 回拨较小时等待时钟追上, 期间其他调用同样阻塞在锁上
+	time.Sleep(backward)
+	for now < s.timestamp {
+		now = currentMillis()
+	}
+	return now, nil
+}
+
+// waitNextMillis 等待到上次生成时间的下一毫秒, 需持有锁
+func (s *Snowflake) waitNextMillis() int64 {
+	now := currentMillis()
+	for now <= s.timestamp {
+		now = currentMillis()
+	}
+	return now
+}
+
+func (s *Snowflake) compose(now int64) uint64 {
+	return uint64((now-twepoch)<<timestampShift | (s.workerid << workeridShift) | (s.sequence))
+}
+
+func currentMillis() int64 {
+	return time.Now().UnixNano() / 1000000
+}
+
+// Parts 雪花 ID 的组成部分
+type Parts struct {
+	// Time 生成时间, 精确到毫秒
+	Time     time.Time
+	WorkerId int64
+	Sequence int64
+}
+
+// Decode 解析雪花 ID 的生成时间, 机器 ID 和序列号
+func Decode(id uint64) Parts {
+	return Parts{
+		Time:     time.UnixMilli(int64(id>>timestampShift) + twepoch),
+		WorkerId: int64(id>>workeridShift) & workeridMax,
+		Sequence: int64(id) & sequenceMask,
+	}
 }
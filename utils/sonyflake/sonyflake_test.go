@@ -1,8 +1,15 @@
 package sonyflake
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/zeromicro/go-zero/core/conf"
 )
 
 func TestGetSonyflakeID(t *testing.T) {
@@ -10,3 +17,163 @@ func TestGetSonyflakeID(t *testing.T) {
 	fmt.Println(ids)
 
 }
+
+func TestBatchGenerateUnique(t *testing.T) {
+	sf := MustSnowflake(7)
+
+	ids, err := sf.BatchGenerateE(10000)
+	assert.Nil(t, err)
+	for i := 0; i < 2; i++ {
+		id, err := sf.GenerateE()
+		assert.Nil(t, err)
+		ids = append(ids, id)
+	}
+
+	seen := make(map[uint64]struct{}, len(ids))
+	for i, v := range ids {
+		_, ok := seen[v]
+		assert.False(t, ok, "duplicate id %d", v)
+		seen[v] = struct{}{}
+		if i > 0 {
+			assert.Greater(t, v, ids[i-1])
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	sf := MustSnowflake(513)
+
+	before := time.Now().Truncate(time.Millisecond)
+	id, err := sf.GenerateE()
+	assert.Nil(t, err)
+	id2, err := sf.GenerateE()
+	assert.Nil(t, err)
+	after := time.Now()
+
+	parts := Decode(id)
+	assert.Equal(t, int64(513), parts.WorkerId)
+	assert.False(t, parts.Time.Before(before))
+	assert.False(t, parts.Time.After(after))
+
+	parts2 := Decode(id2)
+	if parts2.Time.Equal(parts.Time) {
+		assert.Equal(t, parts.Sequence+1, parts2.Sequence)
+	} else {
+		assert.Equal(t, int64(0), parts2.Sequence)
+	}
+}
+
+func TestClockRollback(t *testing.T) {
+	sf := MustSnowflake(1, WithRollbackPolicy(RollbackError, 0))
+	sf.timestamp = currentMillis() + 1000
+
+	_, err := sf.GenerateE()
+	assert.True(t, errors.Is(err, ErrClockRollback))
+	_, err = sf.BatchGenerateE(2)
+	assert.True(t, errors.Is(err, ErrClockRollback))
+
+	sf = MustSnowflake(1, WithRollbackPolicy(RollbackWait, 100*time.Millisecond))
+	last := currentMillis() + 1000
+	sf.timestamp = last
+	_, err = sf.GenerateE()
+	assert.True(t, errors.Is(err, ErrClockRollback))
+
+	last = currentMillis() + 30
+	sf.timestamp = last
+	id, err := sf.GenerateE()
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, Decode(id).Time.UnixMilli(), last)
+}
+
+func TestWorkerIdFromEnv(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "12", want: 12},
+		{value: "admin-rpc-3", want: 3},
+		{value: "", wantErr: true},
+		{value: "admin-rpc", wantErr: true},
+		{value: "1024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("SONYFLAKE_TEST_POD", tt.value)
+		got, err := WorkerIdFromEnv("SONYFLAKE_TEST_POD")
+		if tt.wantErr {
+			assert.NotNil(t, err, tt.value)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestConfNewSnowflake(t *testing.T) {
+	var c Conf
+	err := conf.LoadFromYamlBytes([]byte("WorkerIdSource: env\nWorkerIdEnv: SONYFLAKE_TEST_POD\nRollbackPolicy: error"), &c)
+	assert.Nil(t, err)
+	assert.Equal(t, RollbackError, c.RollbackPolicy)
+	assert.Equal(t, time.Second, c.MaxRollbackWait)
+
+	t.Setenv("SONYFLAKE_TEST_POD", "app-5")
+	sf, err := c.NewSnowflake(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), sf.WorkerId())
+	id, err := sf.GenerateE()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), Decode(id).WorkerId)
+
+	_, err = Conf{WorkerIdSource: WorkerIdSourceRedis}.NewSnowflake(nil)
+	assert.NotNil(t, err)
+
+	_, err = Conf{WorkerId: 2048}.NewSnowflake(nil)
+	assert.NotNil(t, err)
+}
+
+func TestLeaseLost(t *testing.T) {
+	sf := MustSnowflake(3)
+	sf.setWorker(-1, ErrWorkerLeaseLost)
+	_, err := sf.GenerateE()
+	assert.Equal(t, ErrWorkerLeaseLost, err)
+	assert.PanicsWithValue(t, ErrWorkerLeaseLost, func() { sf.Generate() })
+
+	sf.setWorker(9, nil)
+	id, err := sf.GenerateE()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9), Decode(id).WorkerId)
+}
+
+func TestLeaseExpired(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rds := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rds.Close()
+
+	sf, err := Conf{WorkerIdSource: WorkerIdSourceRedis, LeaseTTL: time.Minute}.NewSnowflake(rds)
+	assert.Nil(t, err)
+	defer sf.Close()
+	id, err := sf.GenerateE()
+	assert.Nil(t, err)
+	assert.Equal(t, sf.WorkerId(), Decode(id).WorkerId)
+
+	// the lease expires before the renewal notices it
+	sf.allocator.lease.Store(&workerLease{workerId: sf.WorkerId(), deadline: time.Now().Add(-time.Millisecond)})
+	_, err = sf.GenerateE()
+	assert.Equal(t, ErrWorkerLeaseLost, err)
+	_, err = sf.BatchGenerateE(2)
+	assert.Equal(t, ErrWorkerLeaseLost, err)
+
+	// the lease of another worker id is reacquired but not set yet
+	sf.allocator.lease.Store(&workerLease{workerId: sf.WorkerId() + 1, deadline: time.Now().Add(time.Minute)})
+	_, err = sf.GenerateE()
+	assert.Equal(t, ErrWorkerLeaseLost, err)
+
+	sf.allocator.lease.Store(&workerLease{workerId: sf.WorkerId(), deadline: time.Now().Add(time.Minute)})
+	_, err = sf.GenerateE()
+	assert.Nil(t, err)
+
+	assert.Nil(t, sf.Close())
+	_, err = sf.GenerateE()
+	assert.Equal(t, ErrWorkerLeaseLost, err)
+}
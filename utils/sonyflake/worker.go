@@ -0,0 +1,226 @@
+package sonyflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/threading"
+	"mingyang.com/admin-common/config"
+	"mingyang.com/admin-common/utils/uuidx"
+)
+
+// DefaultLeaseTTL Redis 机器 ID 租约的默认时长
+const DefaultLeaseTTL = 30 * time.Second
+
+var (
+	// ErrWorkerLeaseLost Redis 机器 ID 租约丢失且尚未重新分配
+	ErrWorkerLeaseLost = errors.New("sonyflake: worker id lease is lost")
+	// ErrNoFreeWorkerId 所有机器 ID 都已被占用
+	ErrNoFreeWorkerId = errors.New("sonyflake: no free worker id")
+)
+
+var (
+	renewLeaseScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	releaseLeaseScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// WorkerIdFromEnv 从环境变量读取机器 ID, 值为数字或 StatefulSet 的 Pod 名称 (如 app-3 取序号 3)
+func WorkerIdFromEnv(name string) (int64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, fmt.Errorf("sonyflake: environment variable %s is empty", name)
+	}
+
+	ordinal := value
+	if i := strings.LastIndexByte(value, '-'); i >= 0 {
+		ordinal = value[i+1:]
+	}
+
+	id, err := strconv.ParseInt(ordinal, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sonyflake: invalid worker id %q in environment variable %s", value, name)
+	}
+	if id < 0 || id > workeridMax {
+		return 0, errors.New("workerid must be between 0 and 1023")
+	}
+	return id, nil
+}
+
+// RedisWorkerAllocator 通过 Redis 租约为每个实例分配唯一的机器 ID, 并定时续约
+type RedisWorkerAllocator struct {
+	rds redis.UniversalClient
+	ttl time.Duration
+	// owner 实例标识, 只能续约和释放自己的租约
+	owner string
+
+	mu        sync.Mutex
+	workerId  int64
+	renewedAt time.Time
+	closed    bool
+	stop      chan struct{}
+	// lease 当前持有的租约, 生成 ID 时无锁读取
+	lease atomic.Pointer[workerLease]
+}
+
+// workerLease 机器 ID 租约, deadline 前 Redis 中的租约不会过期
+type workerLease struct {
+	workerId int64
+	deadline time.Time
+}
+
+// NewRedisWorkerAllocator 创建分配器, ttl <= 0 时使用 DefaultLeaseTTL
+func NewRedisWorkerAllocator(rds redis.UniversalClient, ttl time.Duration) *RedisWorkerAllocator {
+	if ttl <= 0 {
+		ttl = DefaultLeaseTTL
+	}
+
+	return &RedisWorkerAllocator{
+		rds:      rds,
+		ttl:      ttl,
+		owner:    uuidx.NewUUID().String(),
+		workerId: -1,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Acquire 从随机位置开始依次尝试占用空闲的机器 ID
+func (a *RedisWorkerAllocator) Acquire(ctx context.Context) (int64, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.workerId >= 0 {
+		return a.workerId, nil
+	}
+	return a.acquire(ctx)
+}
+
+func (a *RedisWorkerAllocator) acquire(ctx context.Context) (int64, error) {
+	start := rand.Int64N(workeridMax + 1)
+	for i := int64(0); i <= workeridMax; i++ {
+		id := (start + i) % (workeridMax + 1)
+
+		now := time.Now()
+		ok, err := a.rds.SetNX(ctx, workerKey(id), a.owner, a.ttl).Result()
+		if err != nil {
+			return -1, err
+		}
+		if ok {
+			a.workerId, a.renewedAt = id, now
+			a.lease.Store(&workerLease{workerId: id, deadline: now.Add(a.ttl)})
+			return id, nil
+		}
+	}
+	return -1, ErrNoFreeWorkerId
+}
+
+// KeepAlive 每 1/3 租约时长续约一次, 租约丢失后重新分配机器 ID, 并通过 onChange 通知.
+// 续约失败且租约已过期时以 ErrWorkerLeaseLost 通知, 此时不应继续使用原机器 ID.
+func (a *RedisWorkerAllocator) KeepAlive(onChange func(workerId int64, err error)) {
+	threading.GoSafe(func() {
+		ticker := time.NewTicker(a.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-a.stop:
+				return
+			case <-ticker.C:
+				a.renew(onChange)
+			}
+		}
+	})
+}
+
+func (a *RedisWorkerAllocator) renew(onChange func(workerId int64, err error)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.ttl/3)
+	defer cancel()
+
+	if a.workerId >= 0 {
+		now := time.Now()
+		n, err := renewLeaseScript.Run(ctx, a.rds, []string{workerKey(a.workerId)}, a.owner,
+			a.ttl.Milliseconds()).Int64()
+		switch {
+		case err != nil:
+			logx.Errorw("failed to renew sonyflake worker id lease", logx.Field("workerId", a.workerId),
+				logx.Field("detail", err.Error()))
+			if time.Since(a.renewedAt) < a.ttl {
+				return
+			}
+		case n == 1:
+			a.renewedAt = now
+			a.lease.Store(&workerLease{workerId: a.workerId, deadline: now.Add(a.ttl)})
+			return
+		}
+
+		logx.Errorw("sonyflake worker id lease is lost", logx.Field("workerId", a.workerId))
+		a.workerId = -1
+		a.lease.Store(nil)
+		onChange(-1, ErrWorkerLeaseLost)
+	}
+
+	id, err := a.acquire(ctx)
+	if err != nil {
+		logx.Errorw("failed to acquire sonyflake worker id", logx.Field("detail", err.Error()))
+		return
+	}
+
+	logx.Infow("sonyflake worker id is reacquired", logx.Field("workerId", id))
+	onChange(id, nil)
+}
+
+// Close 停止续约并释放租约
+func (a *RedisWorkerAllocator) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+	close(a.stop)
+
+	if a.workerId < 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.ttl/3)
+	defer cancel()
+
+	a.lease.Store(nil)
+	err := releaseLeaseScript.Run(ctx, a.rds, []string{workerKey(a.workerId)}, a.owner).Err()
+	a.workerId = -1
+	return err
+}
+
+// holds 返回是否持有 workerId 的未过期租约
+func (a *RedisWorkerAllocator) holds(workerId int64) bool {
+	l := a.lease.Load()
+	return l != nil && l.workerId == workerId && time.Now().Before(l.deadline)
+}
+
+func workerKey(id int64) string {
+	return config.RedisSonyflakeWorkerPrefix + strconv.FormatInt(id, 10)
+}